@@ -0,0 +1,62 @@
+package clients
+
+import (
+	"net/http"
+	"payment-service/constants"
+	errPayment "payment-service/constants/error/payment"
+	"payment-service/domain/dto"
+)
+
+type IPaymentGateway interface {
+	Provider() constants.PaymentProvider
+	CreatePaymentLink(*dto.PaymentRequest) (*dto.GatewayPaymentLink, error)
+	GetTransaction(string) (*dto.GatewayTransaction, error)
+	CancelTransaction(string) error
+	ExpireTransaction(string) error
+	RefundTransaction(string, *dto.GatewayRefundRequest) (*dto.GatewayRefundResponse, error)
+	ParseNotification(http.Header, []byte) (*dto.GatewayTransaction, error)
+}
+
+type GatewayRegistry struct {
+	defaultProvider constants.PaymentProvider
+	gateways        map[constants.PaymentProvider]IPaymentGateway
+}
+
+type IGatewayRegistry interface {
+	Get(constants.PaymentProvider) (IPaymentGateway, error)
+	Default() IPaymentGateway
+}
+
+func NewGatewayRegistry(defaultProvider constants.PaymentProvider, gateways ...IPaymentGateway) IGatewayRegistry {
+	registry := &GatewayRegistry{
+		defaultProvider: defaultProvider,
+		gateways:        make(map[constants.PaymentProvider]IPaymentGateway, len(gateways)),
+	}
+
+	for _, gateway := range gateways {
+		registry.gateways[gateway.Provider()] = gateway
+	}
+
+	if _, ok := registry.gateways[defaultProvider]; !ok && len(gateways) > 0 {
+		registry.defaultProvider = gateways[0].Provider()
+	}
+
+	return registry
+}
+
+func (g *GatewayRegistry) Get(provider constants.PaymentProvider) (IPaymentGateway, error) {
+	if provider == "" {
+		return g.Default(), nil
+	}
+
+	gateway, ok := g.gateways[provider]
+	if !ok {
+		return nil, errPayment.ErrUnsupportedProvider
+	}
+
+	return gateway, nil
+}
+
+func (g *GatewayRegistry) Default() IPaymentGateway {
+	return g.gateways[g.defaultProvider]
+}
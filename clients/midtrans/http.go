@@ -0,0 +1,19 @@
+package clients
+
+import (
+	"github.com/midtrans/midtrans-go"
+	"io"
+	"strings"
+)
+
+type baseURLHttpClient struct {
+	midtrans.HttpClient
+	env     midtrans.EnvironmentType
+	baseURL string
+}
+
+func (c *baseURLHttpClient) Call(method string, url string, apiKey *string, options *midtrans.ConfigOptions, body io.Reader, result interface{}) *midtrans.Error {
+	url = strings.Replace(url, c.env.SnapURL(), c.baseURL, 1)
+	url = strings.Replace(url, c.env.BaseUrl(), c.baseURL, 1)
+	return c.HttpClient.Call(method, url, apiKey, options, body, result)
+}
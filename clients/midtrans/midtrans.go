@@ -1,35 +1,71 @@
 package clients
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/midtrans/midtrans-go"
+	"github.com/midtrans/midtrans-go/coreapi"
 	"github.com/midtrans/midtrans-go/snap"
 	"github.com/sirupsen/logrus"
+	"net/http"
+	"payment-service/common/money"
+	"payment-service/common/util"
+	"payment-service/constants"
 	errConstant "payment-service/constants/error/payment"
+	errRefund "payment-service/constants/error/refund"
 	"payment-service/domain/dto"
+	"strings"
 	"time"
 )
 
+const midtransCurrency = "IDR"
+
 type MidtransClient struct {
 	ServerKey    string
 	IsProduction bool
+	BaseURL      string
 }
 
 type IMidtransClient interface {
-	CreatePaymentLink(request *dto.PaymentRequest) (*MidtransData, error)
+	Provider() constants.PaymentProvider
+	CreatePaymentLink(request *dto.PaymentRequest) (*dto.GatewayPaymentLink, error)
+	GetTransaction(orderID string) (*dto.GatewayTransaction, error)
+	CancelTransaction(orderID string) error
+	ExpireTransaction(orderID string) error
+	RefundTransaction(orderID string, request *dto.GatewayRefundRequest) (*dto.GatewayRefundResponse, error)
+	ParseNotification(header http.Header, body []byte) (*dto.GatewayTransaction, error)
 }
 
-func NewMidtransClient(serverKey string, isProduction bool) *MidtransClient {
+func NewMidtransClient(serverKey string, isProduction bool, baseURL string) *MidtransClient {
 	return &MidtransClient{
 		ServerKey:    serverKey,
 		IsProduction: isProduction,
+		BaseURL:      strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+func (client *MidtransClient) Provider() constants.PaymentProvider {
+	return constants.ProviderMidtrans
+}
+
+func (client *MidtransClient) validateAmount(amount money.Money) error {
+	if !strings.EqualFold(amount.Currency, midtransCurrency) {
+		return errConstant.ErrUnsupportedCurrency
+	}
+
+	if !amount.IsWhole() {
+		return errConstant.ErrFractionalAmount
 	}
+
+	return nil
 }
 
-func (client *MidtransClient) CreatePaymentLink(request *dto.PaymentRequest) (*MidtransData, error) {
-	var (
-		snapClient   snap.Client
-		isProduction = midtrans.Sandbox
-	)
+func (client *MidtransClient) CreatePaymentLink(request *dto.PaymentRequest) (*dto.GatewayPaymentLink, error) {
+	var snapClient snap.Client
+
+	if err := client.validateAmount(request.Amount); err != nil {
+		return nil, err
+	}
 
 	expiryDatetime := request.ExpiredAt
 	currentTime := time.Now()
@@ -50,34 +86,40 @@ func (client *MidtransClient) CreatePaymentLink(request *dto.PaymentRequest) (*M
 		expiryDuration = int64(duration.Hours() / 24)
 	}
 
-	if client.IsProduction {
-		isProduction = midtrans.Production
-	}
-
-	snapClient.New(client.ServerKey, isProduction)
+	snapClient.New(client.ServerKey, client.environment())
+	snapClient.HttpClient = client.httpClient(snapClient.HttpClient)
 	req := &snap.Request{
 		TransactionDetails: midtrans.TransactionDetails{
 			OrderID:  request.OrderID,
-			GrossAmt: int64(request.Amount),
-		},
-		CustomerDetail: &midtrans.CustomerDetails{
-			FName: request.CustomerDetail.Name,
-			Email: request.CustomerDetail.Email,
-			Phone: request.CustomerDetail.Phone,
-		},
-		Items: &[]midtrans.ItemDetails{
-			{
-				ID:    request.ItemDetails[0].ID,
-				Price: int64(request.ItemDetails[0].Amount),
-				Qty:   int32(request.ItemDetails[0].Quantity),
-				Name:  request.ItemDetails[0].Name,
-			},
+			GrossAmt: request.Amount.Units(),
 		},
 		Expiry: &snap.ExpiryDetails{
 			Unit:     expiryUnit,
 			Duration: expiryDuration,
 		},
 	}
+	if request.CustomerDetail != nil {
+		req.CustomerDetail = &midtrans.CustomerDetails{
+			FName: request.CustomerDetail.Name,
+			Email: request.CustomerDetail.Email,
+			Phone: request.CustomerDetail.Phone,
+		}
+	}
+	if len(request.ItemDetails) > 0 {
+		items := make([]midtrans.ItemDetails, 0, len(request.ItemDetails))
+		for _, item := range request.ItemDetails {
+			if err := client.validateAmount(item.Amount); err != nil {
+				return nil, err
+			}
+			items = append(items, midtrans.ItemDetails{
+				ID:    item.ID,
+				Price: item.Amount.Units(),
+				Qty:   int32(item.Quantity),
+				Name:  item.Name,
+			})
+		}
+		req.Items = &items
+	}
 
 	response, err := snapClient.CreateTransaction(req)
 	if err != nil {
@@ -85,8 +127,140 @@ func (client *MidtransClient) CreatePaymentLink(request *dto.PaymentRequest) (*M
 		return nil, err
 	}
 
-	return &MidtransData{
+	return &dto.GatewayPaymentLink{
 		RedirectURL: response.RedirectURL,
 		Token:       response.Token,
 	}, nil
 }
+
+func (client *MidtransClient) environment() midtrans.EnvironmentType {
+	if client.IsProduction {
+		return midtrans.Production
+	}
+
+	return midtrans.Sandbox
+}
+
+func (client *MidtransClient) httpClient(httpClient midtrans.HttpClient) midtrans.HttpClient {
+	if client.BaseURL == "" {
+		return httpClient
+	}
+
+	return &baseURLHttpClient{
+		HttpClient: httpClient,
+		env:        client.environment(),
+		baseURL:    client.BaseURL,
+	}
+}
+
+func (client *MidtransClient) coreClient() coreapi.Client {
+	var coreClient coreapi.Client
+	coreClient.New(client.ServerKey, client.environment())
+	coreClient.HttpClient = client.httpClient(coreClient.HttpClient)
+	return coreClient
+}
+
+func (client *MidtransClient) wrapError(action string, err *midtrans.Error) error {
+	if err.GetStatusCode() == http.StatusNotFound {
+		return errConstant.ErrTransactionNotFound
+	}
+
+	logrus.Errorf("Error %s transaction: %v", action, err)
+	return err
+}
+
+// isRejection reports whether midtrans definitively refused the request, as
+// opposed to a timeout or server error where the outcome is unknown.
+func isRejection(statusCode int) bool {
+	return statusCode >= http.StatusBadRequest &&
+		statusCode < http.StatusInternalServerError &&
+		statusCode != http.StatusRequestTimeout &&
+		statusCode != http.StatusTooManyRequests
+}
+
+func (client *MidtransClient) CancelTransaction(orderID string) error {
+	coreClient := client.coreClient()
+	_, err := coreClient.CancelTransaction(orderID)
+	if err != nil {
+		return client.wrapError("cancel", err)
+	}
+
+	return nil
+}
+
+func (client *MidtransClient) ExpireTransaction(orderID string) error {
+	coreClient := client.coreClient()
+	_, err := coreClient.ExpireTransaction(orderID)
+	if err != nil {
+		return client.wrapError("expire", err)
+	}
+
+	return nil
+}
+
+func (client *MidtransClient) GetTransaction(orderID string) (*dto.GatewayTransaction, error) {
+	coreClient := client.coreClient()
+	response, err := coreClient.CheckTransaction(orderID)
+	if err != nil {
+		return nil, client.wrapError("check", err)
+	}
+
+	transactionStatus := constants.PaymentStatusString(response.TransactionStatus)
+	if !transactionStatus.IsValid() {
+		return nil, errConstant.ErrUnknownTransactionStatus
+	}
+
+	parsedOrderID, parseErr := util.ParseGatewayOrderID(response.OrderID)
+	if parseErr != nil {
+		return nil, errConstant.ErrPaymentNotFound
+	}
+
+	transaction := &dto.GatewayTransaction{
+		Provider:       client.Provider(),
+		OrderID:        parsedOrderID,
+		GatewayOrderID: response.OrderID,
+		TransactionID:  response.TransactionID,
+		Status:         transactionStatus.WithFraudStatus(constants.FraudStatus(response.FraudStatus)),
+		StatusCode:     response.StatusCode,
+		PaymentType:    response.PaymentType,
+		GrossAmount:    response.GrossAmount,
+		Currency:       response.Currency,
+	}
+	if len(response.VaNumbers) > 0 {
+		transaction.VANumber = &response.VaNumbers[0].VANumber
+		transaction.Bank = &response.VaNumbers[0].Bank
+	}
+	if response.Acquirer != "" {
+		transaction.Acquirer = &response.Acquirer
+	}
+
+	transaction.RawPayload, _ = json.Marshal(response)
+	return transaction, nil
+}
+
+func (client *MidtransClient) RefundTransaction(orderID string, request *dto.GatewayRefundRequest) (*dto.GatewayRefundResponse, error) {
+	if err := client.validateAmount(request.Amount); err != nil {
+		return nil, err
+	}
+
+	coreClient := client.coreClient()
+	response, err := coreClient.RefundTransaction(orderID, &coreapi.RefundReq{
+		RefundKey: request.RefundKey,
+		Amount:    request.Amount.Units(),
+		Reason:    request.Reason,
+	})
+	if err != nil {
+		if isRejection(err.GetStatusCode()) {
+			logrus.Errorf("Error refund transaction: %v", err)
+			return nil, fmt.Errorf("%w: %s", errRefund.ErrRefundRejected, err.GetMessage())
+		}
+		return nil, client.wrapError("refund", err)
+	}
+
+	refundAmount, _ := money.Parse(response.RefundAmount, request.Amount.Currency)
+	return &dto.GatewayRefundResponse{
+		RefundKey: response.RefundKey,
+		Amount:    refundAmount,
+		Status:    response.TransactionStatus,
+	}, nil
+}
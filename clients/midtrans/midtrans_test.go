@@ -0,0 +1,112 @@
+package clients
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"payment-service/common/money"
+	errConstant "payment-service/constants/error/payment"
+	errRefund "payment-service/constants/error/refund"
+	"payment-service/domain/dto"
+	"testing"
+	"time"
+)
+
+func unreachableGateway(t *testing.T) *MidtransClient {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		t.Errorf("unexpected gateway call %s %s", request.Method, request.URL.Path)
+		writer.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	return NewMidtransClient(testServerKey, false, server.URL)
+}
+
+func TestCreatePaymentLinkRejectsUnsupportedAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *dto.PaymentRequest
+		wantErr error
+	}{
+		{
+			name:    "non idr currency",
+			request: &dto.PaymentRequest{Amount: money.New(1500, "USD")},
+			wantErr: errConstant.ErrUnsupportedCurrency,
+		},
+		{
+			name: "non idr item",
+			request: &dto.PaymentRequest{
+				Amount:      money.New(15000000, "IDR"),
+				ItemDetails: []dto.ItemDetail{{ID: "sku-1", Name: "item", Quantity: 1, Amount: money.New(1500, "USD")}},
+			},
+			wantErr: errConstant.ErrUnsupportedCurrency,
+		},
+		{
+			name:    "fractional idr",
+			request: &dto.PaymentRequest{Amount: money.New(15000050, "IDR")},
+			wantErr: errConstant.ErrFractionalAmount,
+		},
+	}
+
+	client := unreachableGateway(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.request.OrderID = testOrderID
+			tt.request.ExpiredAt = time.Now().Add(time.Hour)
+
+			_, err := client.CreatePaymentLink(tt.request)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreatePaymentLink() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRefundTransactionRejectsUnsupportedCurrency(t *testing.T) {
+	_, err := unreachableGateway(t).RefundTransaction(testOrderID, &dto.GatewayRefundRequest{
+		RefundKey: "refund-1",
+		Amount:    money.New(2500, "USD"),
+	})
+	if !errors.Is(err, errConstant.ErrUnsupportedCurrency) {
+		t.Errorf("RefundTransaction() error = %v, want %v", err, errConstant.ErrUnsupportedCurrency)
+	}
+}
+
+func TestRefundTransactionClassifiesGatewayErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantRejected bool
+	}{
+		{name: "rejected in body", status: http.StatusOK, body: `{"status_code":"412","status_message":"Merchant cannot modify the status of the transaction"}`, wantRejected: true},
+		{name: "bad request", status: http.StatusBadRequest, body: `{"status_message":"invalid refund amount"}`, wantRejected: true},
+		{name: "server error", status: http.StatusInternalServerError, body: `{"status_message":"internal error"}`},
+		{name: "gateway timeout", status: http.StatusGatewayTimeout, body: `{}`},
+		{name: "too many requests", status: http.StatusTooManyRequests, body: `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				writer.Header().Set("Content-Type", "application/json")
+				writer.WriteHeader(tt.status)
+				_, _ = writer.Write([]byte(tt.body))
+			}))
+			t.Cleanup(server.Close)
+
+			_, err := NewMidtransClient(testServerKey, false, server.URL).RefundTransaction(testOrderID, &dto.GatewayRefundRequest{
+				RefundKey: "refund-1",
+				Amount:    money.New(2500000, "IDR"),
+			})
+			if err == nil {
+				t.Fatalf("RefundTransaction() error = nil")
+			}
+			if rejected := errors.Is(err, errRefund.ErrRefundRejected); rejected != tt.wantRejected {
+				t.Errorf("RefundTransaction() error = %v, rejected = %v, want %v", err, rejected, tt.wantRejected)
+			}
+		})
+	}
+}
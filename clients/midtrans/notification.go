@@ -0,0 +1,75 @@
+package clients
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"payment-service/common/util"
+	errConstant "payment-service/constants/error/payment"
+	"payment-service/domain/dto"
+	"strings"
+)
+
+func (client *MidtransClient) verifySignature(request *dto.WebHook) error {
+	if request.SignatureKey == "" {
+		return errConstant.ErrInvalidSignature
+	}
+
+	signature := util.GenerateSHA512(fmt.Sprintf("%s%s%s%s",
+		request.OrderID,
+		request.StatusCode,
+		request.GrossAmount,
+		client.ServerKey,
+	))
+	if subtle.ConstantTimeCompare([]byte(signature), []byte(strings.ToLower(request.SignatureKey))) != 1 {
+		return errConstant.ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (client *MidtransClient) ParseNotification(_ http.Header, body []byte) (*dto.GatewayTransaction, error) {
+	var request dto.WebHook
+	err := json.Unmarshal(body, &request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.verifySignature(&request)
+	if err != nil {
+		return nil, err
+	}
+
+	if !request.TransactionStatus.IsValid() {
+		return nil, errConstant.ErrUnknownTransactionStatus
+	}
+
+	orderID, err := util.ParseGatewayOrderID(request.OrderID)
+	if err != nil {
+		return nil, errConstant.ErrPaymentNotFound
+	}
+
+	transaction := &dto.GatewayTransaction{
+		Provider:       client.Provider(),
+		OrderID:        orderID,
+		GatewayOrderID: request.OrderID,
+		TransactionID:  request.TransactionID,
+		Status:         request.TransactionStatus.WithFraudStatus(request.FraudStatus),
+		StatusCode:     request.StatusCode,
+		PaymentType:    request.PaymentType,
+		Acquirer:       request.Acquirer,
+		GrossAmount:    request.GrossAmount,
+		Currency:       request.Currency,
+		RawPayload:     body,
+	}
+	if len(request.VANumbers) > 0 {
+		transaction.VANumber = &request.VANumbers[0].VaNumber
+		transaction.Bank = &request.VANumbers[0].Bank
+	}
+	if len(request.Refunds) > 0 {
+		transaction.RefundKey = &request.Refunds[len(request.Refunds)-1].RefundKey
+	}
+
+	return transaction, nil
+}
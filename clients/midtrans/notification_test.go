@@ -0,0 +1,168 @@
+package clients
+
+import (
+	"encoding/json"
+	"errors"
+	"payment-service/common/util"
+	"payment-service/constants"
+	errConstant "payment-service/constants/error/payment"
+	"payment-service/domain/dto"
+	"strings"
+	"testing"
+)
+
+const (
+	testServerKey = "SB-Mid-server-test"
+	testOrderID   = "3f1c2b9e-8a7d-4c21-9f55-0e6a4b1d2c3f"
+)
+
+func sign(orderID, statusCode, grossAmount string) string {
+	return util.GenerateSHA512(orderID + statusCode + grossAmount + testServerKey)
+}
+
+func notification(mutate func(*dto.WebHook)) []byte {
+	request := dto.WebHook{
+		OrderID:           testOrderID,
+		TransactionID:     "9aed5972-5b6a-401e-894b-a32c91ed1a3a",
+		TransactionStatus: constants.SettlementString,
+		StatusCode:        "200",
+		GrossAmount:       "150000.00",
+		Currency:          "IDR",
+		PaymentType:       "bank_transfer",
+		FraudStatus:       constants.FraudAccept,
+		VANumbers:         []dto.VANumber{{VaNumber: "8808123456", Bank: "bca"}},
+	}
+	request.SignatureKey = sign(request.OrderID, request.StatusCode, request.GrossAmount)
+	if mutate != nil {
+		mutate(&request)
+	}
+
+	body, _ := json.Marshal(request)
+	return body
+}
+
+func TestParseNotificationSignature(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    []byte
+		wantErr error
+	}{
+		{
+			name: "valid signature",
+			body: notification(nil),
+		},
+		{
+			name: "uppercase signature",
+			body: notification(func(request *dto.WebHook) {
+				request.SignatureKey = strings.ToUpper(request.SignatureKey)
+			}),
+		},
+		{
+			name: "tampered gross amount",
+			body: notification(func(request *dto.WebHook) {
+				request.GrossAmount = "1.00"
+			}),
+			wantErr: errConstant.ErrInvalidSignature,
+		},
+		{
+			name: "tampered status code",
+			body: notification(func(request *dto.WebHook) {
+				request.StatusCode = "201"
+			}),
+			wantErr: errConstant.ErrInvalidSignature,
+		},
+		{
+			name: "tampered order id",
+			body: notification(func(request *dto.WebHook) {
+				request.OrderID = "00000000-0000-0000-0000-000000000000"
+			}),
+			wantErr: errConstant.ErrInvalidSignature,
+		},
+		{
+			name: "signed with another server key",
+			body: notification(func(request *dto.WebHook) {
+				request.SignatureKey = util.GenerateSHA512(request.OrderID + request.StatusCode + request.GrossAmount + "other-key")
+			}),
+			wantErr: errConstant.ErrInvalidSignature,
+		},
+		{
+			name: "missing signature",
+			body: notification(func(request *dto.WebHook) {
+				request.SignatureKey = ""
+			}),
+			wantErr: errConstant.ErrInvalidSignature,
+		},
+	}
+
+	client := NewMidtransClient(testServerKey, false, "")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction, err := client.ParseNotification(nil, tt.body)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if transaction != nil {
+					t.Errorf("transaction = %+v, want nil", transaction)
+				}
+				return
+			}
+
+			if transaction.Provider != constants.ProviderMidtrans {
+				t.Errorf("Provider = %s", transaction.Provider)
+			}
+			if transaction.OrderID.String() != testOrderID || transaction.Status != constants.SettlementString {
+				t.Errorf("transaction = %+v", transaction)
+			}
+			if transaction.VANumber == nil || *transaction.VANumber != "8808123456" {
+				t.Errorf("VANumber = %v", transaction.VANumber)
+			}
+			if string(transaction.RawPayload) != string(tt.body) {
+				t.Errorf("RawPayload was not preserved")
+			}
+		})
+	}
+}
+
+func TestParseNotificationAttemptOrderID(t *testing.T) {
+	body := notification(func(request *dto.WebHook) {
+		request.OrderID = testOrderID + "-3"
+		request.SignatureKey = sign(request.OrderID, request.StatusCode, request.GrossAmount)
+	})
+
+	transaction, err := NewMidtransClient(testServerKey, false, "").ParseNotification(nil, body)
+	if err != nil {
+		t.Fatalf("ParseNotification() error = %v", err)
+	}
+
+	if transaction.OrderID.String() != testOrderID || transaction.GatewayOrderID != testOrderID+"-3" {
+		t.Errorf("OrderID = %s, GatewayOrderID = %s", transaction.OrderID, transaction.GatewayOrderID)
+	}
+}
+
+func TestParseNotificationFraudStatus(t *testing.T) {
+	body := notification(func(request *dto.WebHook) {
+		request.TransactionStatus = constants.CaptureString
+		request.FraudStatus = constants.FraudChallenge
+	})
+
+	transaction, err := NewMidtransClient(testServerKey, false, "").ParseNotification(nil, body)
+	if err != nil {
+		t.Fatalf("ParseNotification() error = %v", err)
+	}
+
+	if transaction.Status != constants.ChallengeString {
+		t.Errorf("Status = %s, want %s", transaction.Status, constants.ChallengeString)
+	}
+}
+
+func TestParseNotificationUnknownStatus(t *testing.T) {
+	body := notification(func(request *dto.WebHook) {
+		request.TransactionStatus = "chargeback"
+	})
+
+	_, err := NewMidtransClient(testServerKey, false, "").ParseNotification(nil, body)
+	if !errors.Is(err, errConstant.ErrUnknownTransactionStatus) {
+		t.Fatalf("error = %v, want %v", err, errConstant.ErrUnknownTransactionStatus)
+	}
+}
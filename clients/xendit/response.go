@@ -0,0 +1,89 @@
+package clients
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	InvoicePending = "PENDING"
+	InvoicePaid    = "PAID"
+	InvoiceSettled = "SETTLED"
+	InvoiceExpired = "EXPIRED"
+)
+
+type InvoiceCustomer struct {
+	GivenNames   string `json:"given_names,omitempty"`
+	Email        string `json:"email,omitempty"`
+	MobileNumber string `json:"mobile_number,omitempty"`
+}
+
+type InvoiceItem struct {
+	Name     string      `json:"name"`
+	Quantity int         `json:"quantity"`
+	Price    json.Number `json:"price"`
+}
+
+type InvoiceRequest struct {
+	ExternalID      string           `json:"external_id"`
+	Amount          json.Number      `json:"amount"`
+	Description     string           `json:"description,omitempty"`
+	InvoiceDuration int64            `json:"invoice_duration"`
+	Currency        string           `json:"currency"`
+	Customer        *InvoiceCustomer `json:"customer,omitempty"`
+	Items           []InvoiceItem    `json:"items,omitempty"`
+}
+
+type InvoiceResponse struct {
+	ID                 string       `json:"id"`
+	ExternalID         string       `json:"external_id"`
+	Status             string       `json:"status"`
+	Amount             json.Number  `json:"amount"`
+	PaidAmount         *json.Number `json:"paid_amount"`
+	Currency           string       `json:"currency"`
+	InvoiceURL         string       `json:"invoice_url"`
+	ExpiryDate         string       `json:"expiry_date"`
+	PaymentMethod      string       `json:"payment_method"`
+	BankCode           string       `json:"bank_code"`
+	PaymentChannel     string       `json:"payment_channel"`
+	PaymentDestination string       `json:"payment_destination"`
+	PaidAt             string       `json:"paid_at"`
+}
+
+type RefundRequest struct {
+	InvoiceID   string      `json:"invoice_id"`
+	ReferenceID string      `json:"reference_id"`
+	Amount      json.Number `json:"amount"`
+	Reason      string      `json:"reason"`
+}
+
+type RefundResponse struct {
+	ID          string      `json:"id"`
+	ReferenceID string      `json:"reference_id"`
+	Amount      json.Number `json:"amount"`
+	Status      string      `json:"status"`
+}
+
+type ResponseError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("xendit response: %s", e.Message)
+}
+
+// IsRejection reports whether xendit definitively refused the request, as
+// opposed to a timeout or server error where the outcome is unknown.
+func (e *ResponseError) IsRejection() bool {
+	return e.StatusCode >= http.StatusBadRequest &&
+		e.StatusCode < http.StatusInternalServerError &&
+		e.StatusCode != http.StatusRequestTimeout &&
+		e.StatusCode != http.StatusTooManyRequests
+}
+
+type ErrorResponse struct {
+	ErrorCode string `json:"error_code"`
+	Message   string `json:"message"`
+}
@@ -0,0 +1,275 @@
+package clients
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"net/url"
+	"payment-service/clients/config"
+	"payment-service/common/money"
+	"payment-service/common/util"
+	"payment-service/constants"
+	errConstant "payment-service/constants/error/payment"
+	errRefund "payment-service/constants/error/refund"
+	"payment-service/domain/dto"
+	"time"
+)
+
+const callbackTokenHeader = "X-Callback-Token"
+
+var invoiceRanks = map[string]int{
+	InvoiceExpired: 1,
+	InvoicePending: 2,
+	InvoicePaid:    3,
+	InvoiceSettled: 3,
+}
+
+type XenditClient struct {
+	client        config.IClientConfig
+	secretKey     string
+	callbackToken string
+}
+
+func NewXenditClient(client config.IClientConfig, secretKey, callbackToken string) *XenditClient {
+	return &XenditClient{
+		client:        client,
+		secretKey:     secretKey,
+		callbackToken: callbackToken,
+	}
+}
+
+func (x *XenditClient) Provider() constants.PaymentProvider {
+	return constants.ProviderXendit
+}
+
+func (x *XenditClient) mapStatus(status string) (constants.PaymentStatusString, error) {
+	switch status {
+	case InvoicePending:
+		return constants.PendingString, nil
+	case InvoicePaid, InvoiceSettled:
+		return constants.SettlementString, nil
+	case InvoiceExpired:
+		return constants.ExpireString, nil
+	}
+
+	return "", errConstant.ErrUnknownTransactionStatus
+}
+
+func (x *XenditClient) grossAmount(invoice *InvoiceResponse) string {
+	amount, err := money.Parse(invoice.Amount.String(), invoice.Currency)
+	if err != nil {
+		return invoice.Amount.String()
+	}
+
+	return amount.Decimal()
+}
+
+func (x *XenditClient) toTransaction(invoice *InvoiceResponse, raw []byte) (*dto.GatewayTransaction, error) {
+	status, err := x.mapStatus(invoice.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	orderID, err := util.ParseGatewayOrderID(invoice.ExternalID)
+	if err != nil {
+		return nil, errConstant.ErrPaymentNotFound
+	}
+
+	transaction := &dto.GatewayTransaction{
+		Provider:       x.Provider(),
+		OrderID:        orderID,
+		GatewayOrderID: invoice.ExternalID,
+		TransactionID:  invoice.ID,
+		Status:         status,
+		StatusCode:     invoice.Status,
+		PaymentType:    invoice.PaymentMethod,
+		GrossAmount:    x.grossAmount(invoice),
+		Currency:       invoice.Currency,
+		RawPayload:     raw,
+	}
+	if invoice.PaymentDestination != "" {
+		transaction.VANumber = &invoice.PaymentDestination
+	}
+	if invoice.BankCode != "" {
+		transaction.Bank = &invoice.BankCode
+	} else if invoice.PaymentChannel != "" {
+		transaction.Bank = &invoice.PaymentChannel
+	}
+
+	return transaction, nil
+}
+
+func (x *XenditClient) request(method, path string, body any, result any) error {
+	request := x.client.Client().Clone().
+		SetBasicAuth(x.secretKey, "").
+		CustomMethod(method, fmt.Sprintf("%s%s", x.client.BaseURL(), path))
+	if body != nil {
+		request = request.Send(body)
+	}
+
+	resp, responseBody, errs := request.EndBytes()
+	if len(errs) > 0 {
+		logrus.Errorf("Error xendit request %s %s: %v", method, path, errs[0])
+		return errs[0]
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errConstant.ErrTransactionNotFound
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var errorResponse ErrorResponse
+		_ = json.Unmarshal(responseBody, &errorResponse)
+		logrus.Errorf("Error xendit response %s %s: %d %s", method, path, resp.StatusCode, errorResponse.Message)
+		return &ResponseError{StatusCode: resp.StatusCode, Message: errorResponse.Message}
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(responseBody, result)
+}
+
+// findInvoice picks the invoice of the given attempt. Xendit lists invoices
+// newest first, so a paid invoice wins over a pending one, which wins over an
+// expired one, and ties go to the newest.
+func (x *XenditClient) findInvoice(orderID string) (*InvoiceResponse, error) {
+	var invoices []InvoiceResponse
+	err := x.request(http.MethodGet, fmt.Sprintf("/v2/invoices?external_id=%s", url.QueryEscape(orderID)), nil, &invoices)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *InvoiceResponse
+	for i := range invoices {
+		invoice := &invoices[i]
+		if invoice.ExternalID != orderID {
+			continue
+		}
+
+		if found == nil || invoiceRanks[invoice.Status] > invoiceRanks[found.Status] {
+			found = invoice
+		}
+	}
+
+	if found == nil {
+		return nil, errConstant.ErrTransactionNotFound
+	}
+
+	return found, nil
+}
+
+func (x *XenditClient) CreatePaymentLink(request *dto.PaymentRequest) (*dto.GatewayPaymentLink, error) {
+	duration := time.Until(request.ExpiredAt)
+	if duration <= 0 {
+		logrus.Errorf("Expired at invalid")
+		return nil, errConstant.ErrExpireAtInvalid
+	}
+
+	invoiceRequest := InvoiceRequest{
+		ExternalID:      request.OrderID,
+		Amount:          json.Number(request.Amount.Decimal()),
+		InvoiceDuration: int64(duration.Seconds()),
+		Currency:        request.Amount.Currency,
+	}
+	if request.Description != nil {
+		invoiceRequest.Description = *request.Description
+	}
+	if request.CustomerDetail != nil {
+		invoiceRequest.Customer = &InvoiceCustomer{
+			GivenNames:   request.CustomerDetail.Name,
+			Email:        request.CustomerDetail.Email,
+			MobileNumber: request.CustomerDetail.Phone,
+		}
+	}
+	for _, item := range request.ItemDetails {
+		invoiceRequest.Items = append(invoiceRequest.Items, InvoiceItem{
+			Name:     item.Name,
+			Quantity: item.Quantity,
+			Price:    json.Number(item.Amount.Decimal()),
+		})
+	}
+
+	var invoice InvoiceResponse
+	err := x.request(http.MethodPost, "/v2/invoices", invoiceRequest, &invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.GatewayPaymentLink{
+		Token:         invoice.ID,
+		RedirectURL:   invoice.InvoiceURL,
+		TransactionID: &invoice.ID,
+	}, nil
+}
+
+func (x *XenditClient) GetTransaction(orderID string) (*dto.GatewayTransaction, error) {
+	invoice, err := x.findInvoice(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, _ := json.Marshal(invoice)
+	return x.toTransaction(invoice, raw)
+}
+
+func (x *XenditClient) CancelTransaction(orderID string) error {
+	return x.ExpireTransaction(orderID)
+}
+
+func (x *XenditClient) ExpireTransaction(orderID string) error {
+	invoice, err := x.findInvoice(orderID)
+	if err != nil {
+		return err
+	}
+
+	return x.request(http.MethodPost, fmt.Sprintf("/invoices/%s/expire!", invoice.ID), nil, nil)
+}
+
+func (x *XenditClient) RefundTransaction(orderID string, request *dto.GatewayRefundRequest) (*dto.GatewayRefundResponse, error) {
+	invoice, err := x.findInvoice(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	var refund RefundResponse
+	err = x.request(http.MethodPost, "/refunds", RefundRequest{
+		InvoiceID:   invoice.ID,
+		ReferenceID: request.RefundKey,
+		Amount:      json.Number(request.Amount.Decimal()),
+		Reason:      request.Reason,
+	}, &refund)
+	if err != nil {
+		var responseErr *ResponseError
+		if errors.As(err, &responseErr) && responseErr.IsRejection() {
+			return nil, fmt.Errorf("%w: %s", errRefund.ErrRefundRejected, responseErr.Message)
+		}
+		return nil, err
+	}
+
+	refundAmount, _ := money.Parse(refund.Amount.String(), request.Amount.Currency)
+	return &dto.GatewayRefundResponse{
+		RefundKey: refund.ReferenceID,
+		Amount:    refundAmount,
+		Status:    refund.Status,
+	}, nil
+}
+
+func (x *XenditClient) ParseNotification(header http.Header, body []byte) (*dto.GatewayTransaction, error) {
+	token := header.Get(callbackTokenHeader)
+	if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(x.callbackToken)) != 1 {
+		return nil, errConstant.ErrInvalidSignature
+	}
+
+	var invoice InvoiceResponse
+	err := json.Unmarshal(body, &invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	return x.toTransaction(&invoice, body)
+}
@@ -0,0 +1,498 @@
+package clients
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"payment-service/clients/config"
+	clients "payment-service/clients/gateway"
+	"payment-service/common/money"
+	"payment-service/constants"
+	errConstant "payment-service/constants/error/payment"
+	errRefund "payment-service/constants/error/refund"
+	"payment-service/domain/dto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testSecretKey     = "xnd_development_secret"
+	testCallbackToken = "callback-token"
+	testOrderID       = "3f1c2b9e-8a7d-4c21-9f55-0e6a4b1d2c3f"
+)
+
+var _ clients.IPaymentGateway = (*XenditClient)(nil)
+
+type fakeXendit struct {
+	mutex    sync.Mutex
+	server   *httptest.Server
+	invoices map[string][]InvoiceResponse
+	expired  []string
+	refunds  []RefundRequest
+	created  []InvoiceRequest
+
+	refundStatus int
+	unfiltered   bool
+}
+
+func newFakeXendit(t *testing.T) *fakeXendit {
+	t.Helper()
+
+	fake := &fakeXendit{invoices: map[string][]InvoiceResponse{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v2/invoices", fake.createInvoice)
+	mux.HandleFunc("GET /v2/invoices", fake.listInvoices)
+	mux.HandleFunc("POST /invoices/{id}/{action}", fake.expireInvoice)
+	mux.HandleFunc("POST /refunds", fake.createRefund)
+
+	fake.server = httptest.NewServer(fake.authenticate(mux))
+	t.Cleanup(fake.server.Close)
+	return fake
+}
+
+func (f *fakeXendit) client() *XenditClient {
+	return NewXenditClient(
+		config.NewClientConfig(config.WithBaseURL(f.server.URL)),
+		testSecretKey,
+		testCallbackToken,
+	)
+}
+
+func (f *fakeXendit) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, _, ok := r.BasicAuth()
+		if !ok || username != testSecretKey {
+			writeJSON(w, http.StatusUnauthorized, ErrorResponse{ErrorCode: "INVALID_API_KEY", Message: "invalid api key"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (f *fakeXendit) put(invoice InvoiceResponse) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.invoices[invoice.ExternalID] = append(f.invoices[invoice.ExternalID], invoice)
+}
+
+func (f *fakeXendit) createInvoice(w http.ResponseWriter, r *http.Request) {
+	var request InvoiceRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{ErrorCode: "API_VALIDATION_ERROR", Message: err.Error()})
+		return
+	}
+
+	invoice := InvoiceResponse{
+		ID:         "inv-" + request.ExternalID,
+		ExternalID: request.ExternalID,
+		Status:     InvoicePending,
+		Amount:     request.Amount,
+		Currency:   request.Currency,
+		InvoiceURL: "https://checkout.xendit.test/" + request.ExternalID,
+	}
+
+	f.mutex.Lock()
+	f.created = append(f.created, request)
+	f.invoices[invoice.ExternalID] = append(f.invoices[invoice.ExternalID], invoice)
+	f.mutex.Unlock()
+
+	writeJSON(w, http.StatusOK, invoice)
+}
+
+func (f *fakeXendit) listInvoices(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	invoices := []InvoiceResponse{}
+	for externalID, created := range f.invoices {
+		if !f.unfiltered && externalID != r.URL.Query().Get("external_id") {
+			continue
+		}
+		for i := len(created) - 1; i >= 0; i-- {
+			invoices = append(invoices, created[i])
+		}
+	}
+	writeJSON(w, http.StatusOK, invoices)
+}
+
+func (f *fakeXendit) expireInvoice(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("action") != "expire!" {
+		writeJSON(w, http.StatusNotFound, ErrorResponse{ErrorCode: "NOT_FOUND", Message: "not found"})
+		return
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.expired = append(f.expired, r.PathValue("id"))
+	writeJSON(w, http.StatusOK, map[string]string{"id": r.PathValue("id"), "status": InvoiceExpired})
+}
+
+func (f *fakeXendit) createRefund(w http.ResponseWriter, r *http.Request) {
+	var request RefundRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{ErrorCode: "API_VALIDATION_ERROR", Message: err.Error()})
+		return
+	}
+
+	if f.refundStatus != 0 {
+		writeJSON(w, f.refundStatus, ErrorResponse{ErrorCode: "REFUND_ERROR", Message: http.StatusText(f.refundStatus)})
+		return
+	}
+
+	f.mutex.Lock()
+	f.refunds = append(f.refunds, request)
+	f.mutex.Unlock()
+
+	writeJSON(w, http.StatusOK, RefundResponse{
+		ID:          "rfd-1",
+		ReferenceID: request.ReferenceID,
+		Amount:      request.Amount,
+		Status:      "SUCCEEDED",
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func TestCreatePaymentLink(t *testing.T) {
+	fake := newFakeXendit(t)
+	description := "booking"
+
+	link, err := fake.client().CreatePaymentLink(&dto.PaymentRequest{
+		OrderID:     testOrderID,
+		Amount:      money.New(15000000, "IDR"),
+		Description: &description,
+		ExpiredAt:   time.Now().Add(time.Hour),
+		ItemDetails: []dto.ItemDetail{
+			{ID: "room", Name: "Room", Amount: money.New(7500000, "IDR"), Quantity: 2},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreatePaymentLink() error = %v", err)
+	}
+
+	if link.RedirectURL != "https://checkout.xendit.test/"+testOrderID {
+		t.Errorf("RedirectURL = %q", link.RedirectURL)
+	}
+	if link.TransactionID == nil || *link.TransactionID != "inv-"+testOrderID {
+		t.Errorf("TransactionID = %v", link.TransactionID)
+	}
+
+	request := fake.created[0]
+	if request.Amount != "150000.00" || request.Currency != "IDR" {
+		t.Errorf("sent amount = %v %s, want 150000.00 IDR", request.Amount, request.Currency)
+	}
+	if request.Description != description {
+		t.Errorf("sent description = %q", request.Description)
+	}
+	if len(request.Items) != 1 || request.Items[0].Price != "75000.00" || request.Items[0].Quantity != 2 {
+		t.Errorf("sent items = %+v", request.Items)
+	}
+	if request.InvoiceDuration <= 0 || request.InvoiceDuration > 3600 {
+		t.Errorf("sent invoice duration = %d", request.InvoiceDuration)
+	}
+}
+
+func TestCreatePaymentLinkRejectsPastExpiry(t *testing.T) {
+	fake := newFakeXendit(t)
+
+	_, err := fake.client().CreatePaymentLink(&dto.PaymentRequest{
+		OrderID:   testOrderID,
+		Amount:    money.New(100, "IDR"),
+		ExpiredAt: time.Now().Add(-time.Minute),
+	})
+	if !errors.Is(err, errConstant.ErrExpireAtInvalid) {
+		t.Fatalf("error = %v, want %v", err, errConstant.ErrExpireAtInvalid)
+	}
+	if len(fake.created) != 0 {
+		t.Errorf("invoice was created for an expired request")
+	}
+}
+
+func TestGetTransactionMapsStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		want   constants.PaymentStatusString
+	}{
+		{status: InvoicePending, want: constants.PendingString},
+		{status: InvoicePaid, want: constants.SettlementString},
+		{status: InvoiceSettled, want: constants.SettlementString},
+		{status: InvoiceExpired, want: constants.ExpireString},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			fake := newFakeXendit(t)
+			fake.put(InvoiceResponse{
+				ID:                 "inv-1",
+				ExternalID:         testOrderID + "-2",
+				Status:             tt.status,
+				Amount:             "150000",
+				Currency:           "IDR",
+				PaymentMethod:      "BANK_TRANSFER",
+				BankCode:           "BCA",
+				PaymentDestination: "8808123456",
+			})
+
+			transaction, err := fake.client().GetTransaction(testOrderID + "-2")
+			if err != nil {
+				t.Fatalf("GetTransaction() error = %v", err)
+			}
+
+			if transaction.Status != tt.want {
+				t.Errorf("Status = %s, want %s", transaction.Status, tt.want)
+			}
+			if transaction.Provider != constants.ProviderXendit {
+				t.Errorf("Provider = %s", transaction.Provider)
+			}
+			if transaction.OrderID.String() != testOrderID || transaction.GatewayOrderID != testOrderID+"-2" {
+				t.Errorf("OrderID = %s, GatewayOrderID = %s", transaction.OrderID, transaction.GatewayOrderID)
+			}
+			if transaction.GrossAmount != "150000.00" || transaction.Currency != "IDR" {
+				t.Errorf("GrossAmount = %s %s", transaction.GrossAmount, transaction.Currency)
+			}
+			if transaction.Bank == nil || *transaction.Bank != "BCA" {
+				t.Errorf("Bank = %v", transaction.Bank)
+			}
+			if transaction.VANumber == nil || *transaction.VANumber != "8808123456" {
+				t.Errorf("VANumber = %v", transaction.VANumber)
+			}
+		})
+	}
+}
+
+func TestCreatePaymentLinkSendsExactDecimalAmount(t *testing.T) {
+	fake := newFakeXendit(t)
+
+	_, err := fake.client().CreatePaymentLink(&dto.PaymentRequest{
+		OrderID:   testOrderID,
+		Amount:    money.New(1000000001, "IDR"),
+		ExpiredAt: time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("CreatePaymentLink() error = %v", err)
+	}
+
+	if request := fake.created[0]; request.Amount != "10000000.01" {
+		t.Errorf("sent amount = %s, want 10000000.01", request.Amount)
+	}
+}
+
+func TestGetTransactionPicksInvoiceOfAttempt(t *testing.T) {
+	tests := []struct {
+		name     string
+		invoices []InvoiceResponse
+		want     string
+	}{
+		{
+			name: "newest pending over older expired",
+			invoices: []InvoiceResponse{
+				{ID: "inv-1", Status: InvoiceExpired},
+				{ID: "inv-2", Status: InvoicePending},
+			},
+			want: "inv-2",
+		},
+		{
+			name: "paid over newer pending",
+			invoices: []InvoiceResponse{
+				{ID: "inv-1", Status: InvoicePaid},
+				{ID: "inv-2", Status: InvoicePending},
+			},
+			want: "inv-1",
+		},
+		{
+			name: "newest of the same status",
+			invoices: []InvoiceResponse{
+				{ID: "inv-1", Status: InvoicePending},
+				{ID: "inv-2", Status: InvoicePending},
+			},
+			want: "inv-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeXendit(t)
+			for _, invoice := range tt.invoices {
+				invoice.ExternalID = testOrderID + "-2"
+				invoice.Amount = "150000"
+				invoice.Currency = "IDR"
+				fake.put(invoice)
+			}
+
+			transaction, err := fake.client().GetTransaction(testOrderID + "-2")
+			if err != nil {
+				t.Fatalf("GetTransaction() error = %v", err)
+			}
+			if transaction.TransactionID != tt.want {
+				t.Errorf("TransactionID = %s, want %s", transaction.TransactionID, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTransactionIgnoresOtherAttempts(t *testing.T) {
+	fake := newFakeXendit(t)
+	fake.unfiltered = true
+	fake.put(InvoiceResponse{ID: "inv-1", ExternalID: testOrderID, Status: InvoicePaid, Amount: "150000", Currency: "IDR"})
+
+	_, err := fake.client().GetTransaction(testOrderID + "-2")
+	if !errors.Is(err, errConstant.ErrTransactionNotFound) {
+		t.Fatalf("error = %v, want %v", err, errConstant.ErrTransactionNotFound)
+	}
+}
+
+func TestGetTransactionUnknownStatus(t *testing.T) {
+	fake := newFakeXendit(t)
+	fake.put(InvoiceResponse{ID: "inv-1", ExternalID: testOrderID, Status: "VOIDED"})
+
+	_, err := fake.client().GetTransaction(testOrderID)
+	if !errors.Is(err, errConstant.ErrUnknownTransactionStatus) {
+		t.Fatalf("error = %v, want %v", err, errConstant.ErrUnknownTransactionStatus)
+	}
+}
+
+func TestGetTransactionNotFound(t *testing.T) {
+	fake := newFakeXendit(t)
+
+	_, err := fake.client().GetTransaction(testOrderID)
+	if !errors.Is(err, errConstant.ErrTransactionNotFound) {
+		t.Fatalf("error = %v, want %v", err, errConstant.ErrTransactionNotFound)
+	}
+}
+
+func TestRequestWithInvalidSecretKey(t *testing.T) {
+	fake := newFakeXendit(t)
+	client := NewXenditClient(config.NewClientConfig(config.WithBaseURL(fake.server.URL)), "wrong", testCallbackToken)
+
+	_, err := client.GetTransaction(testOrderID)
+	if err == nil || !strings.Contains(err.Error(), "invalid api key") {
+		t.Fatalf("error = %v, want xendit error message", err)
+	}
+}
+
+func TestExpireAndCancelTransaction(t *testing.T) {
+	fake := newFakeXendit(t)
+	fake.put(InvoiceResponse{ID: "inv-1", ExternalID: testOrderID, Status: InvoicePending})
+
+	if err := fake.client().ExpireTransaction(testOrderID); err != nil {
+		t.Fatalf("ExpireTransaction() error = %v", err)
+	}
+	if err := fake.client().CancelTransaction(testOrderID); err != nil {
+		t.Fatalf("CancelTransaction() error = %v", err)
+	}
+
+	if len(fake.expired) != 2 || fake.expired[0] != "inv-1" || fake.expired[1] != "inv-1" {
+		t.Errorf("expired invoices = %v", fake.expired)
+	}
+
+	err := fake.client().ExpireTransaction("00000000-0000-0000-0000-000000000000")
+	if !errors.Is(err, errConstant.ErrTransactionNotFound) {
+		t.Errorf("error = %v, want %v", err, errConstant.ErrTransactionNotFound)
+	}
+}
+
+func TestRefundTransaction(t *testing.T) {
+	fake := newFakeXendit(t)
+	fake.put(InvoiceResponse{ID: "inv-1", ExternalID: testOrderID, Status: InvoiceSettled})
+
+	response, err := fake.client().RefundTransaction(testOrderID, &dto.GatewayRefundRequest{
+		RefundKey: "refund-1",
+		Amount:    money.New(2500000, "IDR"),
+		Reason:    "cancelled booking",
+	})
+	if err != nil {
+		t.Fatalf("RefundTransaction() error = %v", err)
+	}
+
+	request := fake.refunds[0]
+	if request.InvoiceID != "inv-1" || request.ReferenceID != "refund-1" || request.Amount != "25000.00" {
+		t.Errorf("sent refund = %+v", request)
+	}
+	if response.RefundKey != "refund-1" || response.Amount != money.New(2500000, "IDR") || response.Status != "SUCCEEDED" {
+		t.Errorf("response = %+v", response)
+	}
+}
+
+func TestRefundTransactionClassifiesGatewayErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantRejected bool
+	}{
+		{name: "validation error", status: http.StatusBadRequest, wantRejected: true},
+		{name: "insufficient balance", status: http.StatusForbidden, wantRejected: true},
+		{name: "server error", status: http.StatusInternalServerError},
+		{name: "gateway timeout", status: http.StatusGatewayTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeXendit(t)
+			fake.put(InvoiceResponse{ID: "inv-1", ExternalID: testOrderID, Status: InvoiceSettled})
+			fake.refundStatus = tt.status
+
+			_, err := fake.client().RefundTransaction(testOrderID, &dto.GatewayRefundRequest{
+				RefundKey: "refund-1",
+				Amount:    money.New(2500000, "IDR"),
+			})
+			if err == nil {
+				t.Fatalf("RefundTransaction() error = nil")
+			}
+			if rejected := errors.Is(err, errRefund.ErrRefundRejected); rejected != tt.wantRejected {
+				t.Errorf("RefundTransaction() error = %v, rejected = %v, want %v", err, rejected, tt.wantRejected)
+			}
+		})
+	}
+}
+
+func TestParseNotification(t *testing.T) {
+	body, _ := json.Marshal(InvoiceResponse{
+		ID:         "inv-1",
+		ExternalID: testOrderID,
+		Status:     InvoicePaid,
+		Amount:     "150000",
+		Currency:   "IDR",
+	})
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "valid token", token: testCallbackToken},
+		{name: "tampered token", token: "forged", wantErr: errConstant.ErrInvalidSignature},
+		{name: "missing token", token: "", wantErr: errConstant.ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.token != "" {
+				header.Set(callbackTokenHeader, tt.token)
+			}
+
+			transaction, err := NewXenditClient(config.NewClientConfig(), testSecretKey, testCallbackToken).
+				ParseNotification(header, body)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if transaction.Status != constants.SettlementString || transaction.TransactionID != "inv-1" {
+				t.Errorf("transaction = %+v", transaction)
+			}
+			if string(transaction.RawPayload) != string(body) {
+				t.Errorf("RawPayload was not preserved")
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"os"
+	"payment-service/constants"
+	"payment-service/repositories"
+	deadLetterService "payment-service/services/dead_letter"
+	"text/tabwriter"
+	"time"
+)
+
+var deadLetterCommand = &cobra.Command{
+	Use:   "dead-letter",
+	Short: "Inspect and re-drive dead-lettered kafka messages",
+}
+
+var deadLetterListCommand = &cobra.Command{
+	Use:   "list",
+	Short: "List dead-lettered messages",
+	Run: func(c *cobra.Command, args []string) {
+		db := initApp()
+		kafka := initKafka()
+		defer kafka.Close()
+
+		var status *constants.DeadLetterStatus
+		if all, _ := c.Flags().GetBool("all"); !all {
+			dead := constants.DeadLetterDead
+			status = &dead
+		}
+
+		service := deadLetterService.NewDeadLetterService(repositories.NewRepositoryRegistry(db), kafka)
+		deadLetters, err := service.GetAll(context.Background(), status)
+		if err != nil {
+			panic(err)
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "UUID\tSOURCE\tTOPIC\tATTEMPTS\tSTATUS\tCREATED AT\tERROR")
+		for _, deadLetter := range deadLetters {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%d\t%s\t%s\t%s\n",
+				deadLetter.UUID,
+				deadLetter.Source,
+				deadLetter.Topic,
+				deadLetter.Attempts,
+				deadLetter.Status,
+				deadLetter.CreatedAt.Format(time.RFC3339),
+				deadLetter.Error,
+			)
+		}
+		writer.Flush()
+	},
+}
+
+var deadLetterRedriveCommand = &cobra.Command{
+	Use:   "redrive [uuid...]",
+	Short: "Publish dead-lettered messages back to their original topic",
+	Run: func(c *cobra.Command, args []string) {
+		db := initApp()
+		kafka := initKafka()
+		defer kafka.Close()
+
+		ctx := context.Background()
+		service := deadLetterService.NewDeadLetterService(repositories.NewRepositoryRegistry(db), kafka)
+		uuids := args
+		if all, _ := c.Flags().GetBool("all"); all {
+			dead := constants.DeadLetterDead
+			deadLetters, err := service.GetAll(ctx, &dead)
+			if err != nil {
+				panic(err)
+			}
+
+			uuids = make([]string, 0, len(deadLetters))
+			for _, deadLetter := range deadLetters {
+				uuids = append(uuids, deadLetter.UUID.String())
+			}
+		}
+
+		for _, uuid := range uuids {
+			err := service.Redrive(ctx, uuid)
+			if err != nil {
+				logrus.Errorf("failed redrive dead letter %s: %v", uuid, err)
+				continue
+			}
+			logrus.Infof("redriven dead letter %s", uuid)
+		}
+	},
+}
+
+func init() {
+	deadLetterListCommand.Flags().Bool("all", false, "include redriven messages")
+	deadLetterRedriveCommand.Flags().Bool("all", false, "redrive every dead-lettered message")
+	deadLetterCommand.AddCommand(deadLetterListCommand, deadLetterRedriveCommand)
+	command.AddCommand(deadLetterCommand)
+}
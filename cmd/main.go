@@ -1,25 +1,41 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"expvar"
 	"fmt"
 	"github.com/didip/tollbooth"
 	"github.com/didip/tollbooth/limiter"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"gorm.io/gorm"
 	"net/http"
+	"os/signal"
 	"payment-service/clients"
+	clientConfig "payment-service/clients/config"
+	gatewayClient "payment-service/clients/gateway"
 	midtransClient "payment-service/clients/midtrans"
+	xenditClient "payment-service/clients/xendit"
 	"payment-service/common/response"
 	"payment-service/config"
 	"payment-service/constants"
 	"payment-service/controllers/http"
 	kafkaClient "payment-service/controllers/kafka"
+	deadLetterConsumer "payment-service/controllers/kafka/dead_letter"
+	orderConsumer "payment-service/controllers/kafka/order"
 	"payment-service/domain/models"
 	"payment-service/middlewares"
 	"payment-service/repositories"
 	"payment-service/routes"
 	"payment-service/services"
+	expiryWorker "payment-service/workers/expiry"
+	outboxWorker "payment-service/workers/outbox"
+	reconcileWorker "payment-service/workers/reconcile"
+	"strings"
+	"syscall"
 	"time"
 )
 
@@ -27,36 +43,30 @@ var command = &cobra.Command{
 	Use:   "serve",
 	Short: "Start the server",
 	Run: func(c *cobra.Command, args []string) {
-		_ = godotenv.Load(".env")
-		config.Init()
-		db, err := config.InitDatabase()
-		if err != nil {
-			panic(err)
-		}
+		db := initApp()
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
 
-		loc, err := time.LoadLocation("Asia/Jakarta")
-		if err != nil {
-			panic(err)
-		}
-		time.Local = loc
+		kafka := initKafka()
+		defer kafka.Close()
 
-		err = db.AutoMigrate(
-			&models.Payment{},
-			&models.PaymentHistory{},
-		)
-		if err != nil {
-			panic(err)
-		}
-
-		kafka := kafkaClient.NewKafkaRegistry(config.Config.Kafka.Brokers)
-		midtrans := midtransClient.NewMidtransClient(config.Config.Midtrans.ServerKey, config.Config.Midtrans.IsProduction)
+		gateway := initGateway()
 		client := clients.NewClientRegistry()
 		repository := repositories.NewRepositoryRegistry(db)
-		service := services.NewServiceRegistry(repository, kafka, midtrans)
+		service := services.NewServiceRegistry(repository, kafka, gateway)
 		controller := controllers.NewControllerRegistry(service)
 
+		consumer := initConsumer(service)
+		defer consumer.Close()
+
+		go outboxWorker.NewOutboxRelay(repository, kafka, service.GetDeadLetter()).Run(ctx)
+		go reconcileWorker.NewReconcileWorker(repository, service.GetPayment()).Run(ctx)
+		go expiryWorker.NewExpirySweeper(repository, service.GetPayment()).Run(ctx)
+		go consumer.Consume(ctx)
+
 		router := gin.Default()
 		router.Use(middlewares.HandlePanic())
+		router.Use(middlewares.RequestID())
 		router.NoRoute(func(c *gin.Context) {
 			c.JSON(http.StatusNotFound, response.Response{
 				Status:  constants.Error,
@@ -72,7 +82,7 @@ var command = &cobra.Command{
 		router.Use(func(c *gin.Context) {
 			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 			c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, GET, PUT")
-			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, x-service-name, x-api-key, x-request-at")
+			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, x-service-name, x-api-key, x-request-at, x-request-id, idempotency-key")
 			c.Next()
 		})
 
@@ -83,16 +93,154 @@ var command = &cobra.Command{
 			},
 		)
 		router.Use(middlewares.RateLimiter(lmt))
+		router.GET("/debug/vars",
+			middlewares.Authenticate(),
+			middlewares.CheckRole([]string{constants.Admin}, client),
+			gin.WrapH(expvar.Handler()),
+		)
 
 		group := router.Group("/api/v1")
-		route := routes.NewRouteRegistry(controller, group, client)
+		route := routes.NewRouteRegistry(controller, group, client, service)
 		route.Serve()
 
-		port := fmt.Sprintf(":%d", config.Config.Port)
-		router.Run(port)
+		server := &http.Server{
+			Addr:    fmt.Sprintf(":%d", config.Config.Port),
+			Handler: router,
+		}
+
+		go func() {
+			err := server.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				panic(err)
+			}
+		}()
+
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		err := server.Shutdown(shutdownCtx)
+		if err != nil {
+			logrus.Errorf("failed shutdown server: %v", err)
+		}
 	},
 }
 
+func initKafka() *kafkaClient.Registry {
+	producer, err := kafkaClient.NewSyncProducer(config.Config.Kafka.Brokers)
+	if err != nil {
+		panic(err)
+	}
+
+	return kafkaClient.NewKafkaRegistry(kafkaClient.NewKafkaProducer(producer))
+}
+
+func initGateway() gatewayClient.IGatewayRegistry {
+	return gatewayClient.NewGatewayRegistry(
+		constants.PaymentProvider(config.Config.DefaultProvider),
+		midtransClient.NewMidtransClient(
+			config.Config.Midtrans.ServerKey,
+			config.Config.Midtrans.IsProduction,
+			config.Config.Midtrans.BaseURL,
+		),
+		xenditClient.NewXenditClient(
+			clientConfig.NewClientConfig(clientConfig.WithBaseURL(config.Config.Xendit.BaseURL)),
+			config.Config.Xendit.SecretKey,
+			config.Config.Xendit.CallbackToken,
+		),
+	)
+}
+
+func initConsumer(service services.IServiceRegistry) kafkaClient.IConsumer {
+	group, err := kafkaClient.NewConsumerGroup(config.Config.Kafka.Brokers, config.Config.Kafka.GroupID)
+	if err != nil {
+		panic(err)
+	}
+
+	consumer := kafkaClient.NewKafkaConsumer(group, deadLetterConsumer.NewDeadLetterHandler(service).HandleDeadLetter)
+	if config.Config.Kafka.OrderTopic != "" {
+		consumer.Register(config.Config.Kafka.OrderTopic, orderConsumer.NewOrderHandler(service).HandleOrder)
+	}
+
+	return consumer
+}
+
+func initApp() *gorm.DB {
+	db := initDatabase()
+	migrateSchema(db)
+
+	pending, err := pendingMoneyColumns(db)
+	if err != nil {
+		panic(err)
+	}
+	if len(pending) > 0 {
+		columns := make([]string, 0, len(pending))
+		for _, column := range pending {
+			columns = append(columns, column.table+"."+column.legacy)
+		}
+		panic(fmt.Errorf("legacy amount columns %s have not been migrated to minor units, run migrate-money first",
+			strings.Join(columns, ", ")))
+	}
+
+	return db
+}
+
+func initDatabase() *gorm.DB {
+	_ = godotenv.Load(".env")
+	config.Init()
+	db, err := config.InitDatabase()
+	if err != nil {
+		panic(err)
+	}
+
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		panic(err)
+	}
+	time.Local = loc
+
+	return db
+}
+
+func migrateSchema(db *gorm.DB) {
+	if db.Migrator().HasIndex(&models.PaymentNotification{}, "idx_payment_notifications_transaction_status") {
+		err := db.Migrator().DropIndex(&models.PaymentNotification{}, "idx_payment_notifications_transaction_status")
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	err := checkDuplicatePayments(db)
+	if err != nil {
+		panic(err)
+	}
+
+	err = migrateIdempotencyKeys(db)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.AutoMigrate(
+		&models.Payment{},
+		&models.PaymentHistory{},
+		&models.PaymentNotification{},
+		&models.Outbox{},
+		&models.DeadLetter{},
+		&models.Refund{},
+		&models.IdempotencyKey{},
+		&models.PaymentAttempt{},
+		&models.PaymentItem{},
+		&models.PaymentReview{},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	err = createPaymentFilterIndexes(db)
+	if err != nil {
+		panic(err)
+	}
+}
+
 func Run() {
 	err := command.Execute()
 	if err != nil {
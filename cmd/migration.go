@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+	"os"
+	"payment-service/common/money"
+	"payment-service/constants"
+	"payment-service/domain/models"
+	"text/tabwriter"
+)
+
+var paymentChildTables = []string{"payment_histories", "refunds", "payment_attempts", "payment_reviews"}
+
+const duplicatePaymentsQuery = `SELECT id, order_id, status, keep_id FROM (
+		SELECT id, order_id, status, FIRST_VALUE(id) OVER (
+			PARTITION BY order_id ORDER BY (status IN (?, ?)) DESC, id DESC
+		) AS keep_id
+		FROM payments
+	) ranked
+	WHERE id <> keep_id
+	ORDER BY order_id, id`
+
+type duplicatePayment struct {
+	ID      uint
+	OrderID string
+	Status  constants.PaymentStatus
+	KeepID  uint
+}
+
+func needsOrderIDDedupe(db *gorm.DB) bool {
+	return db.Migrator().HasTable(&models.Payment{}) && !db.Migrator().HasIndex(&models.Payment{}, "OrderID")
+}
+
+func findDuplicatePayments(db *gorm.DB) ([]duplicatePayment, error) {
+	var duplicates []duplicatePayment
+	if !needsOrderIDDedupe(db) {
+		return duplicates, nil
+	}
+
+	err := db.Raw(duplicatePaymentsQuery, constants.Capture, constants.Settlement).Scan(&duplicates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return duplicates, nil
+}
+
+func checkDuplicatePayments(db *gorm.DB) error {
+	duplicates, err := findDuplicatePayments(db)
+	if err != nil {
+		return err
+	}
+
+	if len(duplicates) > 0 {
+		return fmt.Errorf("%d duplicate payments block the unique order_id index, "+
+			"review them with migrate-order-ids --dry-run and merge them with migrate-order-ids", len(duplicates))
+	}
+
+	return nil
+}
+
+func dedupePaymentOrderIDs(db *gorm.DB, duplicates []duplicatePayment) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, duplicate := range duplicates {
+			for _, table := range paymentChildTables {
+				if !tx.Migrator().HasTable(table) {
+					continue
+				}
+
+				err := tx.Exec(fmt.Sprintf("UPDATE %s SET payment_id = ? WHERE payment_id = ?", table),
+					duplicate.KeepID, duplicate.ID).Error
+				if err != nil {
+					return err
+				}
+			}
+
+			err := tx.Exec("DELETE FROM payments WHERE id = ?", duplicate.ID).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+var migrateOrderIDsCommand = &cobra.Command{
+	Use:   "migrate-order-ids",
+	Short: "Merge duplicate payments per order id so the unique order_id index can be created",
+	Run: func(c *cobra.Command, args []string) {
+		db := initDatabase()
+		dryRun, _ := c.Flags().GetBool("dry-run")
+
+		duplicates, err := findDuplicatePayments(db)
+		if err != nil {
+			panic(err)
+		}
+
+		if len(duplicates) == 0 {
+			fmt.Println("no duplicate payments found")
+			return
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "ORDER ID\tPAYMENT ID\tSTATUS\tMERGED INTO")
+		for _, duplicate := range duplicates {
+			fmt.Fprintf(writer, "%s\t%d\t%s\t%d\n",
+				duplicate.OrderID,
+				duplicate.ID,
+				duplicate.Status.GetStatusString(),
+				duplicate.KeepID,
+			)
+		}
+		writer.Flush()
+
+		if dryRun {
+			fmt.Printf("dry run, %d duplicate payments would be merged\n", len(duplicates))
+			return
+		}
+
+		err = dedupePaymentOrderIDs(db, duplicates)
+		if err != nil {
+			panic(err)
+		}
+		migrateSchema(db)
+
+		fmt.Printf("merged %d duplicate payments\n", len(duplicates))
+	},
+}
+
+func migrateIdempotencyKeys(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&models.IdempotencyKey{}) || db.Migrator().HasColumn(&models.IdempotencyKey{}, "UserUUID") {
+		return nil
+	}
+
+	// keys stored before they were scoped per user cannot be attributed to an owner
+	return db.Migrator().DropTable(&models.IdempotencyKey{})
+}
+
+type moneyColumn struct {
+	table  string
+	legacy string
+	prefix string
+}
+
+var moneyColumns = []moneyColumn{
+	{table: "payments", legacy: "amount", prefix: "amount_"},
+	{table: "refunds", legacy: "amount", prefix: "amount_"},
+}
+
+func pendingMoneyColumns(db *gorm.DB) ([]moneyColumn, error) {
+	var pending []moneyColumn
+	for _, column := range moneyColumns {
+		columnTypes, err := db.Migrator().ColumnTypes(column.table)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, columnType := range columnTypes {
+			nullable, _ := columnType.Nullable()
+			if columnType.Name() == column.legacy && !nullable {
+				pending = append(pending, column)
+			}
+		}
+	}
+
+	return pending, nil
+}
+
+// legacy float columns are kept (nullable) for rollback and dropped in a later release
+func migrateMoneyColumns(db *gorm.DB) ([]string, error) {
+	var migrated []string
+	err := db.Transaction(func(tx *gorm.DB) error {
+		pending, err := pendingMoneyColumns(tx)
+		if err != nil {
+			return err
+		}
+
+		for _, column := range pending {
+			err = tx.Exec(
+				fmt.Sprintf("UPDATE %s SET %sminor = ROUND(%s * ?), %scurrency = ? WHERE %s IS NOT NULL",
+					column.table, column.prefix, column.legacy, column.prefix, column.legacy),
+				money.Factor(constants.DefaultCurrency),
+				constants.DefaultCurrency,
+			).Error
+			if err != nil {
+				return err
+			}
+
+			err = tx.Exec(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL", column.table, column.legacy)).Error
+			if err != nil {
+				return err
+			}
+
+			migrated = append(migrated, column.table+"."+column.legacy)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return migrated, nil
+}
+
+var migrateMoneyCommand = &cobra.Command{
+	Use:   "migrate-money",
+	Short: "Backfill minor-unit amount columns from the legacy float amounts",
+	Run: func(c *cobra.Command, args []string) {
+		db := initDatabase()
+		migrateSchema(db)
+
+		migrated, err := migrateMoneyColumns(db)
+		if err != nil {
+			panic(err)
+		}
+
+		if len(migrated) == 0 {
+			fmt.Println("no legacy amount columns left to migrate")
+			return
+		}
+
+		for _, column := range migrated {
+			fmt.Printf("migrated %s\n", column)
+		}
+	},
+}
+
+func init() {
+	migrateOrderIDsCommand.Flags().Bool("dry-run", false, "only report the payments that would be merged")
+	command.AddCommand(migrateMoneyCommand, migrateOrderIDsCommand)
+}
+
+var paymentFilterIndexes = []string{
+	"CREATE INDEX IF NOT EXISTS idx_payments_amount ON payments (amount_currency, amount_minor)",
+	"CREATE INDEX IF NOT EXISTS idx_payments_bank_lower ON payments (LOWER(bank))",
+	"CREATE INDEX IF NOT EXISTS idx_payments_created_at_id ON payments (created_at, id)",
+}
+
+func createPaymentFilterIndexes(db *gorm.DB) error {
+	for _, statement := range paymentFilterIndexes {
+		err := db.Exec(statement).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error
+	if err != nil {
+		logrus.Warnf("pg_trgm unavailable, description search will not be indexed: %v", err)
+		return nil
+	}
+
+	return db.Exec("CREATE INDEX IF NOT EXISTS idx_payments_description_trgm ON payments USING gin (description gin_trgm_ops)").Error
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"os/signal"
+	"payment-service/repositories"
+	deadLetterService "payment-service/services/dead_letter"
+	outboxWorker "payment-service/workers/outbox"
+	"syscall"
+)
+
+var outboxCommand = &cobra.Command{
+	Use:   "outbox-relay",
+	Short: "Publish pending outbox messages to kafka",
+	Run: func(c *cobra.Command, args []string) {
+		db := initApp()
+
+		kafka := initKafka()
+		defer kafka.Close()
+
+		repository := repositories.NewRepositoryRegistry(db)
+		relay := outboxWorker.NewOutboxRelay(repository, kafka, deadLetterService.NewDeadLetterService(repository, kafka))
+
+		once, _ := c.Flags().GetBool("once")
+		if once {
+			published, err := relay.RelayPending(context.Background())
+			if err != nil {
+				panic(err)
+			}
+			logrus.Infof("published %d outbox messages", published)
+			return
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+		relay.Run(ctx)
+	},
+}
+
+func init() {
+	outboxCommand.Flags().Bool("once", false, "relay a single batch and exit")
+	command.AddCommand(outboxCommand)
+}
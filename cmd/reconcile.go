@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/spf13/cobra"
+	"payment-service/repositories"
+	"payment-service/services"
+	reconcileWorker "payment-service/workers/reconcile"
+)
+
+var reconcileCommand = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Reconcile non-final payments against the payment gateway and print a report",
+	Run: func(c *cobra.Command, args []string) {
+		db := initApp()
+
+		kafka := initKafka()
+		defer kafka.Close()
+
+		repository := repositories.NewRepositoryRegistry(db)
+		service := services.NewServiceRegistry(repository, kafka, initGateway())
+		report, err := reconcileWorker.NewReconcileWorker(repository, service.GetPayment()).Reconcile(context.Background())
+		if err != nil {
+			panic(err)
+		}
+
+		if report == nil {
+			fmt.Println("reconciliation is already running on another instance")
+			return
+		}
+
+		output, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(output))
+	},
+}
+
+func init() {
+	command.AddCommand(reconcileCommand)
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"net/http"
+	"os/signal"
+	midtransSimulator "payment-service/simulators/midtrans"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var simulatorCommand = &cobra.Command{
+	Use:   "midtrans-simulator",
+	Short: "Run a local fake of the midtrans snap and status api",
+	Run: func(c *cobra.Command, args []string) {
+		port, _ := c.Flags().GetInt("port")
+		serverKey, _ := c.Flags().GetString("server-key")
+		webhookURL, _ := c.Flags().GetString("webhook-url")
+		scenarioName, _ := c.Flags().GetString("scenario")
+
+		var scenario midtransSimulator.Scenario
+		if scenarioName != "" {
+			var err error
+			scenario, err = midtransSimulator.GetScenario(scenarioName)
+			if err != nil {
+				panic(err)
+			}
+		}
+
+		baseURL := fmt.Sprintf("http://localhost:%d", port)
+		simulator := midtransSimulator.NewSimulator(serverKey, baseURL, webhookURL, scenario)
+		server := &http.Server{
+			Addr:    fmt.Sprintf(":%d", port),
+			Handler: simulator.Handler(),
+		}
+
+		go func() {
+			err := server.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				panic(err)
+			}
+		}()
+		logrus.Infof("midtrans simulator listening on %s, set midtrans.baseURL to use it", baseURL)
+
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		err := server.Shutdown(shutdownCtx)
+		if err != nil {
+			logrus.Errorf("failed shutdown simulator: %v", err)
+		}
+	},
+}
+
+func init() {
+	simulatorCommand.Flags().Int("port", 9090, "port the simulator listens on")
+	simulatorCommand.Flags().String("server-key", "SB-Mid-server-simulator", "server key used for basic auth and notification signatures")
+	simulatorCommand.Flags().String("webhook-url", "http://localhost:8003/api/v1/payment/webhook/midtrans", "url notifications are sent to")
+	simulatorCommand.Flags().String("scenario", "", fmt.Sprintf("notifications played for every new transaction (%s)",
+		strings.Join(midtransSimulator.ScenarioNames(), ", ")))
+	command.AddCommand(simulatorCommand)
+}
@@ -0,0 +1,170 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"payment-service/constants"
+	errPayment "payment-service/constants/error/payment"
+	"strconv"
+	"strings"
+)
+
+type Money struct {
+	Minor    int64  `json:"minorUnits" gorm:"column:minor;not null;default:0" validate:"gt=0"`
+	Currency string `json:"currency" gorm:"column:currency;type:varchar(3);not null;default:'IDR'" validate:"len=3"`
+}
+
+var exponents = map[string]int{
+	"IDR": 2,
+	"USD": 2,
+	"SGD": 2,
+	"JPY": 0,
+	"KRW": 0,
+}
+
+func normalizeCurrency(currency string) string {
+	if currency == "" {
+		return constants.DefaultCurrency
+	}
+
+	return strings.ToUpper(currency)
+}
+
+func Exponent(currency string) int {
+	exponent, ok := exponents[normalizeCurrency(currency)]
+	if !ok {
+		return 2
+	}
+
+	return exponent
+}
+
+func Factor(currency string) int64 {
+	factor := int64(1)
+	for i := 0; i < Exponent(currency); i++ {
+		factor *= 10
+	}
+
+	return factor
+}
+
+func New(minor int64, currency string) Money {
+	return Money{Minor: minor, Currency: normalizeCurrency(currency)}
+}
+
+func isDigits(value string) bool {
+	for _, char := range value {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func Parse(value, currency string) (Money, error) {
+	value = strings.TrimSpace(value)
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(value, "-")
+
+	whole, fraction, hasFraction := strings.Cut(value, ".")
+	if whole == "" || !isDigits(whole) || !isDigits(fraction) || (hasFraction && fraction == "") {
+		return Money{}, errPayment.ErrInvalidAmount
+	}
+
+	exponent := Exponent(currency)
+	if strings.TrimRight(fraction[min(len(fraction), exponent):], "0") != "" {
+		return Money{}, errPayment.ErrInvalidAmount
+	}
+	fraction = fraction[:min(len(fraction), exponent)]
+	fraction += strings.Repeat("0", exponent-len(fraction))
+
+	minor, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return Money{}, errPayment.ErrInvalidAmount
+	}
+
+	if negative {
+		minor = -minor
+	}
+
+	return New(minor, currency), nil
+}
+
+func (m Money) Units() int64 {
+	return m.Minor / Factor(m.Currency)
+}
+
+func (m Money) IsWhole() bool {
+	return m.Minor%Factor(m.Currency) == 0
+}
+
+func (m Money) SameCurrency(other Money) bool {
+	return normalizeCurrency(m.Currency) == normalizeCurrency(other.Currency)
+}
+
+func (m Money) Add(other Money) Money {
+	return New(m.Minor+other.Minor, m.Currency)
+}
+
+func (m Money) Multiply(quantity int) Money {
+	return New(m.Minor*int64(quantity), m.Currency)
+}
+
+func (m Money) Decimal() string {
+	exponent := Exponent(m.Currency)
+	if exponent == 0 {
+		return strconv.FormatInt(m.Minor, 10)
+	}
+
+	sign := ""
+	minor := m.Minor
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+
+	factor := Factor(m.Currency)
+	return fmt.Sprintf("%s%d.%0*d", sign, minor/factor, exponent, minor%factor)
+}
+
+func (m Money) String() string {
+	return fmt.Sprintf("%s %s", m.Decimal(), normalizeCurrency(m.Currency))
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	switch data[0] {
+	case '{':
+		type plain Money
+		var value plain
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		*m = New(value.Minor, value.Currency)
+		return nil
+	case '"':
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		parsed, err := Parse(value, constants.DefaultCurrency)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	default:
+		parsed, err := Parse(string(data), constants.DefaultCurrency)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	}
+}
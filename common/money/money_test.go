@@ -0,0 +1,58 @@
+package money
+
+import (
+	"errors"
+	errPayment "payment-service/constants/error/payment"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value    string
+		currency string
+		want     Money
+		wantErr  bool
+	}{
+		{value: "150000", currency: "IDR", want: New(15000000, "IDR")},
+		{value: "150000.00", currency: "IDR", want: New(15000000, "IDR")},
+		{value: "150000.5", currency: "IDR", want: New(15000050, "IDR")},
+		{value: " 12.34 ", currency: "USD", want: New(1234, "USD")},
+		{value: "-12.34", currency: "USD", want: New(-1234, "USD")},
+		{value: "1500", currency: "JPY", want: New(1500, "JPY")},
+		{value: "1500.000", currency: "JPY", want: New(1500, "JPY")},
+		{value: "1500.5", currency: "JPY", wantErr: true},
+		{value: "12.345", currency: "USD", wantErr: true},
+		{value: "--5", currency: "IDR", wantErr: true},
+		{value: "-+5", currency: "IDR", wantErr: true},
+		{value: "+5", currency: "IDR", wantErr: true},
+		{value: "5.-1", currency: "IDR", wantErr: true},
+		{value: "5.+1", currency: "IDR", wantErr: true},
+		{value: "1-5", currency: "IDR", wantErr: true},
+		{value: "1e3", currency: "IDR", wantErr: true},
+		{value: "1,000", currency: "IDR", wantErr: true},
+		{value: ".50", currency: "IDR", wantErr: true},
+		{value: "5.", currency: "IDR", wantErr: true},
+		{value: "-", currency: "IDR", wantErr: true},
+		{value: "", currency: "IDR", wantErr: true},
+		{value: "99999999999999999999", currency: "IDR", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := Parse(tt.value, tt.currency)
+			if tt.wantErr {
+				if !errors.Is(err, errPayment.ErrInvalidAmount) {
+					t.Fatalf("Parse(%q) error = %v, want %v", tt.value, err, errPayment.ErrInvalidAmount)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package queryoption
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	errConstant "payment-service/constants/error"
+	"strings"
+	"time"
+)
+
+type Cursor struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ID        uint      `json:"id"`
+	Direction string    `json:"direction"`
+	Backward  bool      `json:"backward,omitempty"`
+}
+
+func (c Cursor) ScanDirection() string {
+	if c.Backward {
+		return Reverse(c.Direction)
+	}
+
+	return c.Direction
+}
+
+func Reverse(direction string) string {
+	if direction == Desc {
+		return Asc
+	}
+
+	return Desc
+}
+
+func KeysetOperator(direction string) string {
+	if direction == Desc {
+		return "<"
+	}
+
+	return ">"
+}
+
+func EncodeCursor(cursor Cursor, secret string) string {
+	payload, _ := json.Marshal(cursor)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + sign(encoded, secret)
+}
+
+func DecodeCursor(token, secret string) (*Cursor, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(sign(encoded, secret))) {
+		return nil, errConstant.ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errConstant.ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	err = json.Unmarshal(payload, &cursor)
+	if err != nil || (cursor.Direction != Asc && cursor.Direction != Desc) {
+		return nil, errConstant.ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+func sign(value, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,118 @@
+package queryoption
+
+import (
+	"gorm.io/gorm/clause"
+	errConstant "payment-service/constants/error"
+	"strings"
+)
+
+const (
+	Asc  = "asc"
+	Desc = "desc"
+)
+
+type Sort struct {
+	Column    string
+	Direction string
+}
+
+type Sortable struct {
+	columns    map[string]string
+	defaults   Sort
+	tiebreaker string
+}
+
+func NewSortable(defaults Sort, tiebreaker string, columns map[string]string) Sortable {
+	return Sortable{
+		columns:    columns,
+		defaults:   defaults,
+		tiebreaker: tiebreaker,
+	}
+}
+
+func ParseDirection(direction *string) (string, error) {
+	if direction == nil || *direction == "" {
+		return Asc, nil
+	}
+
+	switch strings.ToLower(*direction) {
+	case Asc:
+		return Asc, nil
+	case Desc:
+		return Desc, nil
+	default:
+		return "", errConstant.ErrInvalidSortOrder
+	}
+}
+
+func (s Sortable) Resolve(column, direction *string) (Sort, error) {
+	if column == nil || *column == "" {
+		if direction == nil || *direction == "" {
+			return s.defaults, nil
+		}
+
+		parsed, err := ParseDirection(direction)
+		if err != nil {
+			return Sort{}, err
+		}
+		return Sort{Column: s.defaults.Column, Direction: parsed}, nil
+	}
+
+	mapped, ok := s.columns[*column]
+	if !ok {
+		return Sort{}, errConstant.ErrInvalidSortColumn
+	}
+
+	parsed, err := ParseDirection(direction)
+	if err != nil {
+		return Sort{}, err
+	}
+
+	return Sort{Column: mapped, Direction: parsed}, nil
+}
+
+func (s Sortable) OrderBy(sort Sort) clause.OrderBy {
+	desc := sort.Direction == Desc
+	columns := []clause.OrderByColumn{
+		{Column: clause.Column{Name: sort.Column}, Desc: desc},
+	}
+	if s.tiebreaker != "" && s.tiebreaker != sort.Column {
+		columns = append(columns, clause.OrderByColumn{Column: clause.Column{Name: s.tiebreaker}, Desc: desc})
+	}
+
+	return clause.OrderBy{Columns: columns}
+}
+
+func Offset(page, limit int) int {
+	if page < 1 || limit < 1 {
+		return 0
+	}
+
+	return (page - 1) * limit
+}
+
+func TotalPage(total int64, limit int) int {
+	if limit < 1 {
+		return 0
+	}
+
+	return int((total + int64(limit) - 1) / int64(limit))
+}
+
+func NextPage(page, totalPage int) *int {
+	if page >= totalPage {
+		return nil
+	}
+
+	next := page + 1
+	return &next
+}
+
+func PreviousPage(page, totalPage int) *int {
+	if page <= 1 {
+		return nil
+	}
+
+	previous := min(page-1, max(totalPage, 1))
+	return &previous
+}
@@ -0,0 +1,167 @@
+package queryoption
+
+import (
+	"errors"
+	errConstant "payment-service/constants/error"
+	"testing"
+)
+
+var testSortable = NewSortable(
+	Sort{Column: "created_at", Direction: Desc},
+	"id",
+	map[string]string{
+		"createdAt": "created_at",
+		"amount":    "amount_minor",
+		"status":    "status",
+	},
+)
+
+func stringPointer(value string) *string {
+	return &value
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		column    *string
+		direction *string
+		want      Sort
+		wantErr   error
+	}{
+		{name: "defaults", want: Sort{Column: "created_at", Direction: Desc}},
+		{name: "empty values", column: stringPointer(""), direction: stringPointer(""), want: Sort{Column: "created_at", Direction: Desc}},
+		{name: "default column with direction", direction: stringPointer("asc"), want: Sort{Column: "created_at", Direction: Asc}},
+		{name: "whitelisted column", column: stringPointer("amount"), direction: stringPointer("desc"), want: Sort{Column: "amount_minor", Direction: Desc}},
+		{name: "whitelisted column defaults to asc", column: stringPointer("status"), want: Sort{Column: "status", Direction: Asc}},
+		{name: "direction is case insensitive", column: stringPointer("createdAt"), direction: stringPointer("DESC"), want: Sort{Column: "created_at", Direction: Desc}},
+		{name: "raw column name is not whitelisted", column: stringPointer("amount_minor"), wantErr: errConstant.ErrInvalidSortColumn},
+		{name: "injection attempt", column: stringPointer("id; DROP TABLE payments"), wantErr: errConstant.ErrInvalidSortColumn},
+		{name: "invalid direction", column: stringPointer("amount"), direction: stringPointer("sideways"), wantErr: errConstant.ErrInvalidSortOrder},
+		{name: "invalid direction on default column", direction: stringPointer("up"), wantErr: errConstant.ErrInvalidSortOrder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testSortable.Resolve(tt.column, tt.direction)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Resolve() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+	orderBy := testSortable.OrderBy(Sort{Column: "amount_minor", Direction: Desc})
+	if len(orderBy.Columns) != 2 {
+		t.Fatalf("OrderBy() columns = %+v, want column and tiebreaker", orderBy.Columns)
+	}
+	if orderBy.Columns[0].Column.Name != "amount_minor" || !orderBy.Columns[0].Desc {
+		t.Errorf("first column = %+v", orderBy.Columns[0])
+	}
+	if orderBy.Columns[1].Column.Name != "id" || !orderBy.Columns[1].Desc {
+		t.Errorf("tiebreaker = %+v", orderBy.Columns[1])
+	}
+
+	orderBy = NewSortable(Sort{Column: "id", Direction: Asc}, "id", nil).OrderBy(Sort{Column: "id", Direction: Asc})
+	if len(orderBy.Columns) != 1 {
+		t.Errorf("OrderBy() repeated the tiebreaker: %+v", orderBy.Columns)
+	}
+}
+
+func TestOffset(t *testing.T) {
+	tests := []struct {
+		page  int
+		limit int
+		want  int
+	}{
+		{page: 1, limit: 10, want: 0},
+		{page: 2, limit: 10, want: 10},
+		{page: 5, limit: 25, want: 100},
+		{page: 0, limit: 10, want: 0},
+		{page: -1, limit: 10, want: 0},
+		{page: 3, limit: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := Offset(tt.page, tt.limit); got != tt.want {
+			t.Errorf("Offset(%d, %d) = %d, want %d", tt.page, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestTotalPage(t *testing.T) {
+	tests := []struct {
+		total int64
+		limit int
+		want  int
+	}{
+		{total: 0, limit: 10, want: 0},
+		{total: 1, limit: 10, want: 1},
+		{total: 10, limit: 10, want: 1},
+		{total: 11, limit: 10, want: 2},
+		{total: 100, limit: 7, want: 15},
+		{total: 10, limit: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := TotalPage(tt.total, tt.limit); got != tt.want {
+			t.Errorf("TotalPage(%d, %d) = %d, want %d", tt.total, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestNextPage(t *testing.T) {
+	tests := []struct {
+		page      int
+		totalPage int
+		want      *int
+	}{
+		{page: 1, totalPage: 3, want: intPointer(2)},
+		{page: 2, totalPage: 3, want: intPointer(3)},
+		{page: 3, totalPage: 3},
+		{page: 4, totalPage: 3},
+		{page: 1, totalPage: 0},
+	}
+
+	for _, tt := range tests {
+		assertPage(t, "NextPage", tt.page, tt.totalPage, NextPage(tt.page, tt.totalPage), tt.want)
+	}
+}
+
+func TestPreviousPage(t *testing.T) {
+	tests := []struct {
+		page      int
+		totalPage int
+		want      *int
+	}{
+		{page: 1, totalPage: 3},
+		{page: 0, totalPage: 3},
+		{page: 2, totalPage: 3, want: intPointer(1)},
+		{page: 3, totalPage: 3, want: intPointer(2)},
+		{page: 10, totalPage: 3, want: intPointer(3)},
+		{page: 5, totalPage: 0, want: intPointer(1)},
+	}
+
+	for _, tt := range tests {
+		assertPage(t, "PreviousPage", tt.page, tt.totalPage, PreviousPage(tt.page, tt.totalPage), tt.want)
+	}
+}
+
+func intPointer(value int) *int {
+	return &value
+}
+
+func assertPage(t *testing.T, name string, page, totalPage int, got, want *int) {
+	t.Helper()
+
+	switch {
+	case got == nil && want == nil:
+	case got == nil || want == nil:
+		t.Errorf("%s(%d, %d) = %v, want %v", name, page, totalPage, got, want)
+	case *got != *want:
+		t.Errorf("%s(%d, %d) = %d, want %d", name, page, totalPage, *got, *want)
+	}
+}
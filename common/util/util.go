@@ -3,18 +3,23 @@ package util
 import (
 	"bytes"
 	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/hex"
 	"fmt"
 	"github.com/SebastiaanKlippert/go-wkhtmltopdf"
 	"github.com/dustin/go-humanize"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"html/template"
-	"math"
 	"os"
+	"payment-service/common/money"
+	"payment-service/common/queryoption"
+	"payment-service/constants"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type PaginationParam struct {
@@ -34,27 +39,20 @@ type PaginationResult struct {
 	Data         interface{} `json:"data"`
 }
 
-func GeneratePagination(params PaginationParam) PaginationResult {
-	totalPage := int(math.Ceil(float64(params.Count) / float64(params.Limit)))
-
-	var (
-		nextPage     int
-		previousPage int
-	)
-
-	if params.Page < totalPage {
-		nextPage = params.Page + 1
-	}
-
-	if params.Page > totalPage {
-		nextPage = params.Page - 1
-	}
+type CursorPaginationResult struct {
+	Limit      int         `json:"limit"`
+	NextCursor *string     `json:"nextCursor"`
+	PrevCursor *string     `json:"prevCursor"`
+	Data       interface{} `json:"data"`
+}
 
+func GeneratePagination(params PaginationParam) PaginationResult {
+	totalPage := queryoption.TotalPage(params.Count, params.Limit)
 	result := PaginationResult{
 		TotalPage:    totalPage,
 		TotalData:    params.Count,
-		NextPage:     &nextPage,
-		PreviousPage: &previousPage,
+		NextPage:     queryoption.NextPage(params.Page, totalPage),
+		PreviousPage: queryoption.PreviousPage(params.Page, totalPage),
 		Page:         params.Page,
 		Limit:        params.Limit,
 		Data:         params.Data,
@@ -63,6 +61,26 @@ func GeneratePagination(params PaginationParam) PaginationResult {
 	return result
 }
 
+func Backoff(base time.Duration, attempt int) time.Duration {
+	return base * time.Duration(1<<min(max(attempt, 0), 10))
+}
+
+func GatewayOrderID(orderID string, attempt int) string {
+	if attempt <= 1 {
+		return orderID
+	}
+
+	return fmt.Sprintf("%s-%d", orderID, attempt)
+}
+
+func ParseGatewayOrderID(gatewayOrderID string) (uuid.UUID, error) {
+	if len(gatewayOrderID) > 36 && gatewayOrderID[36] == '-' {
+		gatewayOrderID = gatewayOrderID[:36]
+	}
+
+	return uuid.Parse(gatewayOrderID)
+}
+
 func GenerateSHA256(inputString string) string {
 	hash := sha256.New()
 	hash.Write([]byte(inputString))
@@ -71,14 +89,54 @@ func GenerateSHA256(inputString string) string {
 	return hashString
 }
 
-func RupiahFormat(amount *float64) string {
-	stringValue := "0"
+func GenerateSHA512(inputString string) string {
+	hash := sha512.New()
+	hash.Write([]byte(inputString))
+	hashBytes := hash.Sum(nil)
+	hashString := hex.EncodeToString(hashBytes)
+	return hashString
+}
+
+type currencyFormat struct {
+	symbol    string
+	thousands string
+	decimal   string
+	trimWhole bool
+}
+
+var currencyFormats = map[string]currencyFormat{
+	"IDR": {symbol: "Rp ", thousands: ".", decimal: ",", trimWhole: true},
+	"USD": {symbol: "$", thousands: ",", decimal: "."},
+	"SGD": {symbol: "S$", thousands: ",", decimal: "."},
+	"JPY": {symbol: "¥", thousands: ",", decimal: "."},
+	"KRW": {symbol: "₩", thousands: ",", decimal: "."},
+}
+
+func CurrencyFormat(amount *money.Money) string {
+	value := money.New(0, constants.DefaultCurrency)
 	if amount != nil {
-		humanizeValue := humanize.CommafWithDigits(*amount, 0)
-		stringValue = strings.ReplaceAll(humanizeValue, ",", ".")
+		value = money.New(amount.Minor, amount.Currency)
+	}
+
+	format, ok := currencyFormats[value.Currency]
+	if !ok {
+		format = currencyFormat{symbol: value.Currency + " ", thousands: ",", decimal: "."}
+	}
+
+	sign := ""
+	minor := value.Minor
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+
+	factor := money.Factor(value.Currency)
+	stringValue := strings.ReplaceAll(humanize.Comma(minor/factor), ",", format.thousands)
+	if exponent := money.Exponent(value.Currency); exponent > 0 && !(format.trimWhole && minor%factor == 0) {
+		stringValue += fmt.Sprintf("%s%0*d", format.decimal, exponent, minor%factor)
 	}
 
-	return fmt.Sprintf("Rp %s", stringValue)
+	return fmt.Sprintf("%s%s%s", sign, format.symbol, stringValue)
 }
 
 func BindFromJSON(dest any, filename, path string) error {
@@ -0,0 +1,33 @@
+package util
+
+import (
+	"payment-service/common/money"
+	"testing"
+)
+
+func TestCurrencyFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount *money.Money
+		want   string
+	}{
+		{name: "nil", want: "Rp 0"},
+		{name: "rupiah", amount: &money.Money{Minor: 150000000, Currency: "IDR"}, want: "Rp 1.500.000"},
+		{name: "rupiah with cents", amount: &money.Money{Minor: 150050, Currency: "IDR"}, want: "Rp 1.500,50"},
+		{name: "lowercase currency", amount: &money.Money{Minor: 100000, Currency: "idr"}, want: "Rp 1.000"},
+		{name: "dollar", amount: &money.Money{Minor: 123456789, Currency: "USD"}, want: "$1,234,567.89"},
+		{name: "whole dollar", amount: &money.Money{Minor: 500, Currency: "USD"}, want: "$5.00"},
+		{name: "singapore dollar", amount: &money.Money{Minor: 1005, Currency: "SGD"}, want: "S$10.05"},
+		{name: "yen", amount: &money.Money{Minor: 1500000, Currency: "JPY"}, want: "¥1,500,000"},
+		{name: "negative", amount: &money.Money{Minor: -2500, Currency: "USD"}, want: "-$25.00"},
+		{name: "unknown currency", amount: &money.Money{Minor: 123456, Currency: "EUR"}, want: "EUR 1,234.56"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CurrencyFormat(tt.amount); got != tt.want {
+				t.Errorf("CurrencyFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
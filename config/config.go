@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"github.com/sirupsen/logrus"
 	_ "github.com/spf13/viper/remote"
 	"os"
@@ -14,12 +15,18 @@ type AppConfig struct {
 	AppName               string          `json:"appName"`
 	AppEnv                string          `json:"appEnv"`
 	SignatureKey          string          `json:"signatureKey"`
+	CursorSecret          string          `json:"cursorSecret"`
 	Database              Database        `json:"database"`
 	RateLimiterMaxRequest float64         `json:"rateLimiterMaxRequest"`
 	RateLimiterTimeSecond int             `json:"rateLimiterTimeSecond"`
 	InternalService       InternalService `json:"internalService"`
 	Kafka                 Kafka           `json:"kafka"`
 	Midtrans              Midtrans        `json:"midtrans"`
+	Xendit                Xendit          `json:"xendit"`
+	DefaultProvider       string          `json:"defaultProvider"`
+	Outbox                Outbox          `json:"outbox"`
+	Reconcile             Reconcile       `json:"reconcile"`
+	Expiry                Expiry          `json:"expiry"`
 }
 
 type Database struct {
@@ -44,16 +51,47 @@ type User struct {
 }
 
 type Kafka struct {
-	Brokers     []string `json:"brokers"`
-	TimeOutInMS int      `json:"timeOutInMS"`
-	MaxRetry    int      `json:"maxRetry"`
-	Topic       string   `json:"topic"`
+	Brokers          []string `json:"brokers"`
+	TimeOutInMS      int      `json:"timeOutInMS"`
+	MaxRetry         int      `json:"maxRetry"`
+	ProducerMaxRetry int      `json:"producerMaxRetry"`
+	Topic            string   `json:"topic"`
+	TopicV2          string   `json:"topicV2"`
+	EventVersions    []int    `json:"eventVersions"`
+	GroupID          string   `json:"groupID"`
+	OrderTopic       string   `json:"orderTopic"`
+	DeadLetterTopic  string   `json:"deadLetterTopic"`
+	RetryBackoffInMS int      `json:"retryBackoffInMS"`
 }
 
 type Midtrans struct {
 	ServerKey    string `json:"serverKey"`
 	ClientKey    string `json:"clientKey"`
 	IsProduction bool   `json:"isProduction"`
+	BaseURL      string `json:"baseURL"`
+}
+
+type Reconcile struct {
+	IntervalInSecond    int `json:"intervalInSecond"`
+	GracePeriodInSecond int `json:"gracePeriodInSecond"`
+	BatchSize           int `json:"batchSize"`
+}
+
+type Expiry struct {
+	IntervalInSecond int `json:"intervalInSecond"`
+	BatchSize        int `json:"batchSize"`
+}
+
+type Xendit struct {
+	BaseURL       string `json:"baseURL"`
+	SecretKey     string `json:"secretKey"`
+	CallbackToken string `json:"callbackToken"`
+}
+
+type Outbox struct {
+	IntervalInMS int `json:"intervalInMS"`
+	BatchSize    int `json:"batchSize"`
+	MaxRetry     int `json:"maxRetry"`
 }
 
 func Init() {
@@ -65,4 +103,8 @@ func Init() {
 			panic(err)
 		}
 	}
+
+	if Config.CursorSecret == "" {
+		panic(errors.New("cursorSecret must be configured"))
+	}
 }
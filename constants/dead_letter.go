@@ -0,0 +1,24 @@
+package constants
+
+type DeadLetterSource string
+type DeadLetterStatus string
+
+const (
+	DeadLetterProducer DeadLetterSource = "producer"
+	DeadLetterConsumer DeadLetterSource = "consumer"
+
+	DeadLetterDead     DeadLetterStatus = "dead"
+	DeadLetterRedriven DeadLetterStatus = "redriven"
+
+	KafkaHeaderOriginalTopic = "original-topic"
+	KafkaHeaderError         = "error"
+	KafkaHeaderAttempts      = "attempts"
+)
+
+func (d DeadLetterSource) String() string {
+	return string(d)
+}
+
+func (d DeadLetterStatus) String() string {
+	return string(d)
+}
@@ -0,0 +1,15 @@
+package error
+
+import "errors"
+
+var (
+	ErrDeadLetterNotFound        = errors.New("dead letter not found")
+	ErrDeadLetterAlreadyRedriven = errors.New("dead letter already redriven")
+	ErrMalformedMessage          = errors.New("malformed message")
+)
+
+var DeadLetterErrors = []error{
+	ErrDeadLetterNotFound,
+	ErrDeadLetterAlreadyRedriven,
+	ErrMalformedMessage,
+}
@@ -1,18 +1,27 @@
 package error
 
 import (
+	errDeadLetter "payment-service/constants/error/dead_letter"
+	errIdempotency "payment-service/constants/error/idempotency"
 	errPayment "payment-service/constants/error/payment"
+	errRefund "payment-service/constants/error/refund"
 )
 
 func ErrMapping(err error) bool {
 	var (
-		GeneralErrors = GeneralErrors
-		TimeErrors    = errPayment.PaymentErrors
+		GeneralErrors     = GeneralErrors
+		TimeErrors        = errPayment.PaymentErrors
+		DeadLetterErrors  = errDeadLetter.DeadLetterErrors
+		RefundErrors      = errRefund.RefundErrors
+		IdempotencyErrors = errIdempotency.IdempotencyErrors
 	)
 
 	allErrors := make([]error, 0)
 	allErrors = append(allErrors, GeneralErrors...)
 	allErrors = append(allErrors, TimeErrors...)
+	allErrors = append(allErrors, DeadLetterErrors...)
+	allErrors = append(allErrors, RefundErrors...)
+	allErrors = append(allErrors, IdempotencyErrors...)
 
 	for _, item := range allErrors {
 		if err.Error() == item.Error() {
@@ -11,6 +11,9 @@ var (
 	ErrInvalidUploadFile   = errors.New("invalid upload file")
 	ErrSizeTooBig          = errors.New("size too big")
 	ErrForbidden           = errors.New("forbidden")
+	ErrInvalidSortColumn   = errors.New("invalid sort column")
+	ErrInvalidSortOrder    = errors.New("invalid sort order")
+	ErrInvalidCursor       = errors.New("invalid cursor")
 )
 
 var GeneralErrors = []error{
@@ -20,4 +23,7 @@ var GeneralErrors = []error{
 	ErrUnauthorized,
 	ErrInvalidToken,
 	ErrForbidden,
+	ErrInvalidSortColumn,
+	ErrInvalidSortOrder,
+	ErrInvalidCursor,
 }
@@ -0,0 +1,15 @@
+package error
+
+import "errors"
+
+var (
+	ErrIdempotencyKeyReused         = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyRequestInProgress = errors.New("a request with this idempotency key is still being processed")
+	ErrIdempotencyKeyNotFound       = errors.New("idempotency key not found")
+)
+
+var IdempotencyErrors = []error{
+	ErrIdempotencyKeyReused,
+	ErrIdempotencyRequestInProgress,
+	ErrIdempotencyKeyNotFound,
+}
@@ -3,11 +3,47 @@ package error
 import "errors"
 
 var (
-	ErrPaymentNotFound = errors.New("payment not found")
-	ErrExpireAtInvalid = errors.New("expired time must be greater than current time")
+	ErrPaymentNotFound              = errors.New("payment not found")
+	ErrExpireAtInvalid              = errors.New("expired time must be greater than current time")
+	ErrInvalidSignature             = errors.New("invalid signature key")
+	ErrNotificationAlreadyProcessed = errors.New("notification already processed")
+	ErrInvalidStatusTransition      = errors.New("invalid payment status transition")
+	ErrUnknownTransactionStatus     = errors.New("unknown transaction status")
+	ErrTransactionNotFound          = errors.New("transaction not found")
+	ErrPaymentCannotBeCancelled     = errors.New("payment cannot be cancelled")
+	ErrUnsupportedProvider          = errors.New("unsupported payment provider")
+	ErrPaymentAlreadyExists         = errors.New("payment for this order already exists")
+	ErrPaymentAttemptNotFound       = errors.New("payment attempt not found")
+	ErrPaymentCannotBeRenewed       = errors.New("payment link cannot be renewed")
+	ErrItemsAmountMismatch          = errors.New("total of item details must equal the payment amount")
+	ErrPaymentReviewNotFound        = errors.New("payment review not found")
+	ErrPaymentReviewAlreadyResolved = errors.New("payment review already resolved")
+	ErrInvalidAmount                = errors.New("invalid amount")
+	ErrCurrencyMismatch             = errors.New("currency does not match the payment currency")
+	ErrFractionalAmount             = errors.New("amount must be a whole number for this provider")
+	ErrUnsupportedCurrency          = errors.New("currency is not supported by this provider")
+	ErrProviderMismatch             = errors.New("notification provider does not match the payment provider")
 )
 
 var PaymentErrors = []error{
 	ErrPaymentNotFound,
 	ErrExpireAtInvalid,
+	ErrInvalidSignature,
+	ErrNotificationAlreadyProcessed,
+	ErrInvalidStatusTransition,
+	ErrUnknownTransactionStatus,
+	ErrTransactionNotFound,
+	ErrPaymentCannotBeCancelled,
+	ErrUnsupportedProvider,
+	ErrPaymentAlreadyExists,
+	ErrPaymentAttemptNotFound,
+	ErrPaymentCannotBeRenewed,
+	ErrItemsAmountMismatch,
+	ErrPaymentReviewNotFound,
+	ErrPaymentReviewAlreadyResolved,
+	ErrInvalidAmount,
+	ErrCurrencyMismatch,
+	ErrFractionalAmount,
+	ErrUnsupportedCurrency,
+	ErrProviderMismatch,
 }
@@ -0,0 +1,15 @@
+package error
+
+import "errors"
+
+var (
+	ErrPaymentNotRefundable = errors.New("payment cannot be refunded")
+	ErrRefundAmountExceeded = errors.New("refund amount exceeds the remaining paid amount")
+	ErrRefundRejected       = errors.New("refund was rejected by the payment gateway")
+)
+
+var RefundErrors = []error{
+	ErrPaymentNotRefundable,
+	ErrRefundAmountExceeded,
+	ErrRefundRejected,
+}
@@ -3,8 +3,11 @@ package constants
 import "net/textproto"
 
 var (
-	XServiceName  = textproto.CanonicalMIMEHeaderKey("x-service-name")
-	XApiKey       = textproto.CanonicalMIMEHeaderKey("x-api-key")
-	XRequestAt    = textproto.CanonicalMIMEHeaderKey("x-request-at")
-	Authorization = textproto.CanonicalMIMEHeaderKey("authorization")
+	XServiceName       = textproto.CanonicalMIMEHeaderKey("x-service-name")
+	XApiKey            = textproto.CanonicalMIMEHeaderKey("x-api-key")
+	XRequestAt         = textproto.CanonicalMIMEHeaderKey("x-request-at")
+	Authorization      = textproto.CanonicalMIMEHeaderKey("authorization")
+	XRequestID         = textproto.CanonicalMIMEHeaderKey("x-request-id")
+	IdempotencyKey     = textproto.CanonicalMIMEHeaderKey("idempotency-key")
+	IdempotentReplayed = textproto.CanonicalMIMEHeaderKey("idempotent-replayed")
 )
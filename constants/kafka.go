@@ -0,0 +1,18 @@
+package constants
+
+const (
+	KafkaSender = "payment-service"
+
+	KafkaHeaderEventID       = "event-id"
+	KafkaHeaderEventName     = "event-name"
+	KafkaHeaderSchemaVersion = "schema-version"
+	KafkaHeaderCorrelationID = "correlation-id"
+	KafkaHeaderProducedAt    = "produced-at"
+
+	KafkaSchemaVersionV1 = 1
+	KafkaSchemaVersionV2 = 2
+
+	KafkaAggregatePayment = "payment"
+
+	OrderCancelledEvent = "CANCELLED"
+)
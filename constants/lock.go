@@ -0,0 +1,7 @@
+package constants
+
+const (
+	OutboxRelayLockKey int64 = 1001
+	ReconcileLockKey   int64 = 1002
+	ExpirySweepLockKey int64 = 1003
+)
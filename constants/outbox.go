@@ -0,0 +1,13 @@
+package constants
+
+type OutboxStatus string
+
+const (
+	OutboxPending   OutboxStatus = "pending"
+	OutboxPublished OutboxStatus = "published"
+	OutboxFailed    OutboxStatus = "failed"
+)
+
+func (o OutboxStatus) String() string {
+	return string(o)
+}
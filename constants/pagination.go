@@ -0,0 +1,6 @@
+package constants
+
+const (
+	PaginationOffset = "offset"
+	PaginationCursor = "cursor"
+)
@@ -0,0 +1,8 @@
+package constants
+
+type PaymentAttemptStatus string
+
+const (
+	PaymentAttemptActive     PaymentAttemptStatus = "active"
+	PaymentAttemptSuperseded PaymentAttemptStatus = "superseded"
+)
@@ -0,0 +1,11 @@
+package constants
+
+type PaymentReviewStatus string
+
+const (
+	PaymentReviewOpen     PaymentReviewStatus = "open"
+	PaymentReviewApproved PaymentReviewStatus = "approved"
+	PaymentReviewRejected PaymentReviewStatus = "rejected"
+)
+
+const DefaultCurrency = "IDR"
@@ -0,0 +1,12 @@
+package constants
+
+type PaymentProvider string
+
+const (
+	ProviderMidtrans PaymentProvider = "midtrans"
+	ProviderXendit   PaymentProvider = "xendit"
+)
+
+func (p PaymentProvider) String() string {
+	return string(p)
+}
@@ -0,0 +1,12 @@
+package constants
+
+type ReconcileAction string
+
+const (
+	ReconcileApplied  ReconcileAction = "applied"
+	ReconcileRejected ReconcileAction = "rejected"
+	ReconcileNotFound ReconcileAction = "not_found"
+	ReconcileFailed   ReconcileAction = "failed"
+)
+
+var ReconcilableStatuses = []PaymentStatus{Initial, Pending, Authorize, Challenge, Capture}
@@ -0,0 +1,13 @@
+package constants
+
+type RefundStatus string
+
+const (
+	RefundPending   RefundStatus = "pending"
+	RefundSucceeded RefundStatus = "succeeded"
+	RefundFailed    RefundStatus = "failed"
+)
+
+func (r RefundStatus) String() string {
+	return string(r)
+}
@@ -2,31 +2,83 @@ package constants
 
 type PaymentStatus int
 type PaymentStatusString string
+type FraudStatus string
 
 const (
-	Initial    PaymentStatus = 0
-	Pending    PaymentStatus = 100
-	Settlement PaymentStatus = 200
-	Expire     PaymentStatus = 300
-
-	InitialString    PaymentStatusString = "initial"
-	PendingString    PaymentStatusString = "pending"
-	SettlementString PaymentStatusString = "settlement"
-	ExpireString     PaymentStatusString = "expire"
+	Initial       PaymentStatus = 0
+	Pending       PaymentStatus = 100
+	Authorize     PaymentStatus = 110
+	Challenge     PaymentStatus = 120
+	Capture       PaymentStatus = 150
+	Settlement    PaymentStatus = 200
+	Expire        PaymentStatus = 300
+	Cancel        PaymentStatus = 400
+	Deny          PaymentStatus = 500
+	Failure       PaymentStatus = 510
+	Refund        PaymentStatus = 600
+	PartialRefund PaymentStatus = 610
+
+	InitialString       PaymentStatusString = "initial"
+	PendingString       PaymentStatusString = "pending"
+	AuthorizeString     PaymentStatusString = "authorize"
+	ChallengeString     PaymentStatusString = "challenge"
+	CaptureString       PaymentStatusString = "capture"
+	SettlementString    PaymentStatusString = "settlement"
+	ExpireString        PaymentStatusString = "expire"
+	CancelString        PaymentStatusString = "cancel"
+	DenyString          PaymentStatusString = "deny"
+	FailureString       PaymentStatusString = "failure"
+	RefundString        PaymentStatusString = "refund"
+	PartialRefundString PaymentStatusString = "partial_refund"
+
+	FraudAccept    FraudStatus = "accept"
+	FraudChallenge FraudStatus = "challenge"
+	FraudDeny      FraudStatus = "deny"
 )
 
 var mapStatusStringToInt = map[PaymentStatusString]PaymentStatus{
-	InitialString:    Initial,
-	PendingString:    Pending,
-	SettlementString: Settlement,
-	ExpireString:     Expire,
+	InitialString:       Initial,
+	PendingString:       Pending,
+	AuthorizeString:     Authorize,
+	ChallengeString:     Challenge,
+	CaptureString:       Capture,
+	SettlementString:    Settlement,
+	ExpireString:        Expire,
+	CancelString:        Cancel,
+	DenyString:          Deny,
+	FailureString:       Failure,
+	RefundString:        Refund,
+	PartialRefundString: PartialRefund,
 }
 
 var mapStatusIntToString = map[PaymentStatus]PaymentStatusString{
-	Initial:    InitialString,
-	Pending:    PendingString,
-	Settlement: SettlementString,
-	Expire:     ExpireString,
+	Initial:       InitialString,
+	Pending:       PendingString,
+	Authorize:     AuthorizeString,
+	Challenge:     ChallengeString,
+	Capture:       CaptureString,
+	Settlement:    SettlementString,
+	Expire:        ExpireString,
+	Cancel:        CancelString,
+	Deny:          DenyString,
+	Failure:       FailureString,
+	Refund:        RefundString,
+	PartialRefund: PartialRefundString,
+}
+
+var mapStatusStringToEvent = map[PaymentStatusString]string{
+	InitialString:       "INITIAL",
+	PendingString:       "PENDING",
+	AuthorizeString:     "AUTHORIZE",
+	ChallengeString:     "CHALLENGE",
+	CaptureString:       "CAPTURE",
+	SettlementString:    "SETTLEMENT",
+	ExpireString:        "EXPIRE",
+	CancelString:        "CANCEL",
+	DenyString:          "DENY",
+	FailureString:       "FAILURE",
+	RefundString:        "REFUND",
+	PartialRefundString: "PARTIAL_REFUND",
 }
 
 func (p PaymentStatusString) String() string {
@@ -44,3 +96,35 @@ func (p PaymentStatus) GetStatusString() PaymentStatusString {
 func (p PaymentStatusString) GetStatusInt() PaymentStatus {
 	return mapStatusStringToInt[p]
 }
+
+func (p PaymentStatusString) IsValid() bool {
+	_, ok := mapStatusStringToInt[p]
+	return ok
+}
+
+func (p PaymentStatusString) GetEventName() string {
+	return mapStatusStringToEvent[p]
+}
+
+func (p PaymentStatus) IsPaid() bool {
+	return p == Capture || p == Settlement
+}
+
+func (f FraudStatus) String() string {
+	return string(f)
+}
+
+func (p PaymentStatusString) WithFraudStatus(fraudStatus FraudStatus) PaymentStatusString {
+	if p != CaptureString {
+		return p
+	}
+
+	switch fraudStatus {
+	case FraudChallenge:
+		return ChallengeString
+	case FraudDeny:
+		return DenyString
+	}
+
+	return p
+}
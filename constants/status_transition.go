@@ -0,0 +1,47 @@
+package constants
+
+import "slices"
+
+var paymentStatusTransitions = map[PaymentStatus][]PaymentStatus{
+	Initial:       {Pending, Authorize, Challenge, Capture, Settlement, Expire, Cancel, Deny, Failure},
+	Pending:       {Authorize, Challenge, Capture, Settlement, Expire, Cancel, Deny, Failure},
+	Authorize:     {Challenge, Capture, Settlement, Expire, Cancel, Deny, Failure},
+	Challenge:     {Capture, Settlement, Cancel, Deny},
+	Capture:       {Settlement, Cancel, Refund, PartialRefund},
+	Settlement:    {Refund, PartialRefund},
+	PartialRefund: {PartialRefund, Refund},
+	Expire:        {},
+	Cancel:        {},
+	Deny:          {},
+	Failure:       {},
+	Refund:        {},
+}
+
+// paymentStatusRenewals are the edges taken when a payment link is renewed.
+// They are kept out of paymentStatusTransitions so a gateway notification can
+// never move a payment back to initial, and expire stays final for it.
+var paymentStatusRenewals = map[PaymentStatus][]PaymentStatus{
+	Initial: {Initial},
+	Pending: {Initial},
+	Expire:  {Initial},
+}
+
+var ExpirableStatuses = []PaymentStatus{Initial, Pending}
+
+func (p PaymentStatus) CanTransitionTo(next PaymentStatus) bool {
+	for _, status := range paymentStatusTransitions[p] {
+		if status == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p PaymentStatus) CanRenew() bool {
+	return slices.Contains(paymentStatusRenewals[p], Initial)
+}
+
+func (p PaymentStatus) IsFinal() bool {
+	return len(paymentStatusTransitions[p]) == 0
+}
@@ -0,0 +1,118 @@
+package constants
+
+import "testing"
+
+func TestCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from PaymentStatus
+		to   PaymentStatus
+		want bool
+	}{
+		{from: Initial, to: Pending, want: true},
+		{from: Initial, to: Settlement, want: true},
+		{from: Initial, to: Expire, want: true},
+		{from: Pending, to: Settlement, want: true},
+		{from: Pending, to: Capture, want: true},
+		{from: Pending, to: Expire, want: true},
+		{from: Pending, to: Cancel, want: true},
+		{from: Authorize, to: Capture, want: true},
+		{from: Challenge, to: Settlement, want: true},
+		{from: Challenge, to: Deny, want: true},
+		{from: Capture, to: Settlement, want: true},
+		{from: Capture, to: Refund, want: true},
+		{from: Settlement, to: Refund, want: true},
+		{from: Settlement, to: PartialRefund, want: true},
+		{from: PartialRefund, to: PartialRefund, want: true},
+		{from: PartialRefund, to: Refund, want: true},
+
+		{from: Initial, to: Initial, want: false},
+		{from: Initial, to: Refund, want: false},
+		{from: Pending, to: Initial, want: false},
+		{from: Pending, to: Pending, want: false},
+		{from: Pending, to: Refund, want: false},
+		{from: Expire, to: Initial, want: false},
+		{from: Expire, to: Pending, want: false},
+		{from: Expire, to: Settlement, want: false},
+		{from: Challenge, to: Expire, want: false},
+		{from: Capture, to: Pending, want: false},
+		{from: Settlement, to: Pending, want: false},
+		{from: Settlement, to: Cancel, want: false},
+		{from: Settlement, to: Settlement, want: false},
+		{from: Cancel, to: Settlement, want: false},
+		{from: Deny, to: Settlement, want: false},
+		{from: Failure, to: Pending, want: false},
+		{from: Refund, to: PartialRefund, want: false},
+		{from: PartialRefund, to: Settlement, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from.GetStatusString())+"_to_"+string(tt.to.GetStatusString()), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", tt.from.GetStatusString(), tt.to.GetStatusString(), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEveryStatusHasTransitions(t *testing.T) {
+	for status := range mapStatusIntToString {
+		if _, ok := paymentStatusTransitions[status]; !ok {
+			t.Errorf("%s has no entry in the transition table", status.GetStatusString())
+		}
+	}
+}
+
+func TestIsFinal(t *testing.T) {
+	final := map[PaymentStatus]bool{
+		Expire:  true,
+		Cancel:  true,
+		Deny:    true,
+		Failure: true,
+		Refund:  true,
+	}
+
+	for status := range mapStatusIntToString {
+		if got := status.IsFinal(); got != final[status] {
+			t.Errorf("%s.IsFinal() = %v, want %v", status.GetStatusString(), got, final[status])
+		}
+	}
+}
+
+func TestCanRenew(t *testing.T) {
+	renewable := map[PaymentStatus]bool{
+		Initial: true,
+		Pending: true,
+		Expire:  true,
+	}
+
+	for status := range mapStatusIntToString {
+		if got := status.CanRenew(); got != renewable[status] {
+			t.Errorf("%s.CanRenew() = %v, want %v", status.GetStatusString(), got, renewable[status])
+		}
+	}
+}
+
+func TestRenewalDoesNotOpenNotificationTransitions(t *testing.T) {
+	for status := range paymentStatusRenewals {
+		if status != Initial && status.CanTransitionTo(Initial) {
+			t.Errorf("%s can transition to initial outside of a renewal", status.GetStatusString())
+		}
+	}
+}
+
+func TestRenewableAndExpirableStatusesAreNotPaid(t *testing.T) {
+	for status := range paymentStatusRenewals {
+		if status.IsPaid() {
+			t.Errorf("%s is paid and must not be renewable", status.GetStatusString())
+		}
+	}
+
+	for _, status := range ExpirableStatuses {
+		if status.IsPaid() {
+			t.Errorf("%s is paid and must not be expirable", status.GetStatusString())
+		}
+		if !status.CanTransitionTo(Expire) {
+			t.Errorf("%s is expirable but cannot transition to expire", status.GetStatusString())
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	errorValidation "payment-service/common/error"
 	"payment-service/common/response"
+	"payment-service/constants"
 	"payment-service/domain/dto"
 	"payment-service/services"
 )
@@ -19,6 +20,12 @@ type IPaymentController interface {
 	GetByUUID(*gin.Context)
 	Create(*gin.Context)
 	Webhook(*gin.Context)
+	GetAllNotificationWithPagination(*gin.Context)
+	Refund(*gin.Context)
+	Cancel(*gin.Context)
+	Renew(*gin.Context)
+	GetAllReviewWithPagination(*gin.Context)
+	ResolveReview(*gin.Context)
 }
 
 func NewPaymentController(service services.IServiceRegistry) IPaymentController {
@@ -53,7 +60,12 @@ func (p *PaymentController) GetAllWithPagination(ctx *gin.Context) {
 		return
 	}
 
-	result, err := p.service.GetPayment().GetAllWithPagination(ctx, &param)
+	var result any
+	if param.Pagination == constants.PaginationCursor {
+		result, err = p.service.GetPayment().GetAllWithCursor(ctx, &param)
+	} else {
+		result, err = p.service.GetPayment().GetAllWithPagination(ctx, &param)
+	}
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
 			Code: http.StatusBadRequest,
@@ -133,7 +145,86 @@ func (p *PaymentController) Create(ctx *gin.Context) {
 }
 
 func (p *PaymentController) Webhook(ctx *gin.Context) {
-	var request dto.WebHook
+	body, err := ctx.GetRawData()
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	provider := constants.PaymentProvider(ctx.Param("provider"))
+	if provider == "" {
+		provider = constants.ProviderMidtrans
+	}
+
+	err = p.service.GetPayment().Webhook(ctx, &dto.WebhookRequest{
+		Provider: provider,
+		Header:   ctx.Request.Header,
+		Body:     body,
+	})
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (p *PaymentController) GetAllNotificationWithPagination(ctx *gin.Context) {
+	var param dto.PaymentNotificationRequestParam
+	err := ctx.ShouldBindQuery(&param)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	if err = validate.Struct(param); err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errorResponse := errorValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Err:     err,
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errorResponse,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := p.service.GetPayment().GetAllNotificationWithPagination(ctx, &param)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (p *PaymentController) Refund(ctx *gin.Context) {
+	var request dto.RefundRequest
 	err := ctx.ShouldBindJSON(&request)
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
@@ -144,7 +235,39 @@ func (p *PaymentController) Webhook(ctx *gin.Context) {
 		return
 	}
 
-	err = p.service.GetPayment().Webhook(ctx, &request)
+	validate := validator.New()
+	if err = validate.Struct(request); err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errorResponse := errorValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Err:     err,
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errorResponse,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := p.service.GetPayment().Refund(ctx, ctx.Param("uuid"), &request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (p *PaymentController) Cancel(ctx *gin.Context) {
+	result, err := p.service.GetPayment().Cancel(ctx, ctx.Param("uuid"))
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
 			Code: http.StatusBadRequest,
@@ -156,6 +279,136 @@ func (p *PaymentController) Webhook(ctx *gin.Context) {
 
 	response.HttpResponse(response.ParamHTTPResp{
 		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (p *PaymentController) Renew(ctx *gin.Context) {
+	var request dto.RenewPaymentRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	if err = validate.Struct(request); err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errorResponse := errorValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Err:     err,
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errorResponse,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := p.service.GetPayment().Renew(ctx, ctx.Param("uuid"), &request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (p *PaymentController) GetAllReviewWithPagination(ctx *gin.Context) {
+	var param dto.PaymentReviewRequestParam
+	err := ctx.ShouldBindQuery(&param)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	if err = validate.Struct(param); err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errorResponse := errorValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Err:     err,
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errorResponse,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := p.service.GetPayment().GetAllReviewWithPagination(ctx, &param)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (p *PaymentController) ResolveReview(ctx *gin.Context) {
+	var request dto.ResolvePaymentReviewRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	if err = validate.Struct(request); err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errorResponse := errorValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Err:     err,
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errorResponse,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := p.service.GetPayment().ResolveReview(ctx, ctx.Param("uuid"), &request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
 		Gin:  ctx,
 	})
 }
@@ -0,0 +1,180 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"github.com/IBM/sarama"
+	"github.com/sirupsen/logrus"
+	"payment-service/common/util"
+	configApp "payment-service/config"
+	errDeadLetter "payment-service/constants/error/dead_letter"
+	"time"
+)
+
+type ConsumerHandler func(context.Context, *sarama.ConsumerMessage) error
+type DeadLetterHandler func(context.Context, *sarama.ConsumerMessage, error, int) error
+
+type Consumer struct {
+	group      sarama.ConsumerGroup
+	handlers   map[string]ConsumerHandler
+	deadLetter DeadLetterHandler
+}
+
+type IConsumer interface {
+	Register(string, ConsumerHandler)
+	Consume(context.Context) error
+	Close() error
+}
+
+func NewConsumerGroup(brokers []string, groupID string) (sarama.ConsumerGroup, error) {
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_1_0_0
+	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	config.Consumer.Offsets.AutoCommit.Enable = true
+	config.Consumer.Return.Errors = true
+	if configApp.Config.Kafka.TimeOutInMS > 0 {
+		timeout := time.Duration(configApp.Config.Kafka.TimeOutInMS) * time.Millisecond
+		config.Net.DialTimeout = timeout
+		config.Net.ReadTimeout = timeout
+		config.Net.WriteTimeout = timeout
+	}
+
+	group, err := sarama.NewConsumerGroup(brokers, groupID, config)
+	if err != nil {
+		logrus.Errorf("failed create consumer group: %v", err)
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func NewKafkaConsumer(group sarama.ConsumerGroup, deadLetter DeadLetterHandler) *Consumer {
+	return &Consumer{
+		group:      group,
+		handlers:   make(map[string]ConsumerHandler),
+		deadLetter: deadLetter,
+	}
+}
+
+func (c *Consumer) Register(topic string, handler ConsumerHandler) {
+	c.handlers[topic] = handler
+}
+
+func (c *Consumer) topics() []string {
+	topics := make([]string, 0, len(c.handlers))
+	for topic := range c.handlers {
+		topics = append(topics, topic)
+	}
+
+	return topics
+}
+
+func (c *Consumer) Consume(ctx context.Context) error {
+	topics := c.topics()
+	if len(topics) == 0 {
+		return nil
+	}
+
+	go func() {
+		for err := range c.group.Errors() {
+			logrus.Errorf("consumer group error: %v", err)
+		}
+	}()
+
+	for {
+		err := c.group.Consume(ctx, topics, c)
+		if err != nil {
+			if errors.Is(err, sarama.ErrClosedConsumerGroup) {
+				return nil
+			}
+			logrus.Errorf("failed consume: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (c *Consumer) Close() error {
+	err := c.group.Close()
+	if err != nil {
+		logrus.Errorf("failed close consumer group: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *Consumer) handle(ctx context.Context, handler ConsumerHandler, message *sarama.ConsumerMessage) error {
+	var (
+		err      error
+		attempts int
+		maxRetry = max(configApp.Config.Kafka.MaxRetry, 0)
+		backoff  = time.Duration(configApp.Config.Kafka.RetryBackoffInMS) * time.Millisecond
+	)
+
+	for attempts < maxRetry+1 {
+		if attempts > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(util.Backoff(backoff, attempts-1)):
+			}
+		}
+
+		attempts++
+		err = handler(ctx, message)
+		if err == nil {
+			return nil
+		}
+
+		if errors.Is(err, errDeadLetter.ErrMalformedMessage) {
+			break
+		}
+	}
+
+	if c.deadLetter == nil {
+		return err
+	}
+
+	return c.deadLetter(ctx, message, err, attempts)
+}
+
+func (c *Consumer) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (c *Consumer) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case <-session.Context().Done():
+			return nil
+		case message, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+
+			handler, ok := c.handlers[message.Topic]
+			if !ok {
+				session.MarkMessage(message, "")
+				continue
+			}
+
+			err := c.handle(session.Context(), handler, message)
+			if err != nil {
+				logrus.Errorf("failed handle message topic(%s)/partition(%d)/offset(%d): %v",
+					message.Topic, message.Partition, message.Offset, err)
+				return err
+			}
+
+			session.MarkMessage(message, "")
+		}
+	}
+}
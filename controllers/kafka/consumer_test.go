@@ -0,0 +1,57 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/IBM/sarama"
+	configApp "payment-service/config"
+	errDeadLetter "payment-service/constants/error/dead_letter"
+	"testing"
+)
+
+func TestHandleRoutesToDeadLetter(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantAttempts int
+	}{
+		{name: "retryable error", err: errors.New("database unavailable"), wantAttempts: 3},
+		{name: "malformed message", err: fmt.Errorf("%w: unexpected end of JSON input", errDeadLetter.ErrMalformedMessage), wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withKafkaConfig(t, configApp.Kafka{MaxRetry: 2})
+
+			var (
+				calls          int
+				deadLetterErr  error
+				deadLetterRuns int
+			)
+			consumer := NewKafkaConsumer(nil, func(_ context.Context, _ *sarama.ConsumerMessage, err error, attempts int) error {
+				deadLetterRuns++
+				deadLetterErr = err
+				if attempts != tt.wantAttempts {
+					t.Errorf("dead letter attempts = %d, want %d", attempts, tt.wantAttempts)
+				}
+				return nil
+			})
+
+			err := consumer.handle(context.Background(), func(context.Context, *sarama.ConsumerMessage) error {
+				calls++
+				return tt.err
+			}, &sarama.ConsumerMessage{Topic: "order", Value: []byte("{")})
+			if err != nil {
+				t.Fatalf("handle() error = %v", err)
+			}
+
+			if calls != tt.wantAttempts {
+				t.Errorf("handler calls = %d, want %d", calls, tt.wantAttempts)
+			}
+			if deadLetterRuns != 1 || !errors.Is(deadLetterErr, tt.err) {
+				t.Errorf("dead letter runs = %d with %v, want 1 with %v", deadLetterRuns, deadLetterErr, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package kafka
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	"payment-service/constants"
+	"payment-service/domain/dto"
+	"payment-service/services"
+)
+
+type DeadLetterHandler struct {
+	service services.IServiceRegistry
+}
+
+type IDeadLetterHandler interface {
+	HandleDeadLetter(context.Context, *sarama.ConsumerMessage, error, int) error
+}
+
+func NewDeadLetterHandler(service services.IServiceRegistry) IDeadLetterHandler {
+	return &DeadLetterHandler{service: service}
+}
+
+func (d *DeadLetterHandler) HandleDeadLetter(ctx context.Context, message *sarama.ConsumerMessage, cause error, attempts int) error {
+	var messageKey *string
+	if len(message.Key) > 0 {
+		key := string(message.Key)
+		messageKey = &key
+	}
+
+	headers := make(map[string]string, len(message.Headers))
+	for _, header := range message.Headers {
+		headers[string(header.Key)] = string(header.Value)
+	}
+
+	return d.service.GetDeadLetter().Create(ctx, nil, &dto.DeadLetterRequest{
+		Source:     constants.DeadLetterConsumer,
+		Topic:      message.Topic,
+		MessageKey: messageKey,
+		Headers:    headers,
+		Payload:    message.Value,
+		Error:      cause.Error(),
+		Attempts:   attempts,
+	})
+}
@@ -0,0 +1,50 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/IBM/sarama"
+	"github.com/sirupsen/logrus"
+	"payment-service/constants"
+	errDeadLetter "payment-service/constants/error/dead_letter"
+	errPayment "payment-service/constants/error/payment"
+	"payment-service/domain/dto"
+	"payment-service/services"
+)
+
+type OrderHandler struct {
+	service services.IServiceRegistry
+}
+
+type IOrderHandler interface {
+	HandleOrder(context.Context, *sarama.ConsumerMessage) error
+}
+
+func NewOrderHandler(service services.IServiceRegistry) IOrderHandler {
+	return &OrderHandler{service: service}
+}
+
+func (o *OrderHandler) HandleOrder(ctx context.Context, message *sarama.ConsumerMessage) error {
+	var body dto.OrderKafkaMessage
+	err := json.Unmarshal(message.Value, &body)
+	if err != nil {
+		return fmt.Errorf("%w: order message at offset %d: %v", errDeadLetter.ErrMalformedMessage, message.Offset, err)
+	}
+
+	if body.Event.Name != constants.OrderCancelledEvent || body.Body.Data == nil {
+		return nil
+	}
+
+	err = o.service.GetPayment().CancelByOrderID(ctx, body.Body.Data.OrderID.String(), "order cancelled by order-service")
+	if err != nil {
+		if errors.Is(err, errPayment.ErrPaymentNotFound) || errors.Is(err, errPayment.ErrPaymentCannotBeCancelled) {
+			logrus.Warnf("skip cancel for order %s: %v", body.Body.Data.OrderID, err)
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
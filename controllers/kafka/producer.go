@@ -4,47 +4,79 @@ import (
 	"github.com/IBM/sarama"
 	"github.com/sirupsen/logrus"
 	configApp "payment-service/config"
+	"payment-service/constants"
+	"time"
 )
 
 type Kafka struct {
-	brokers []string
+	producer sarama.SyncProducer
 }
 
 type IKafka interface {
-	ProducerMessage(string, []byte) error
+	ProducerMessage(string, string, []byte, map[string]string) error
+	Close() error
 }
 
-func NewKafkaProducer(brokers []string) *Kafka {
-	return &Kafka{
-		brokers: brokers,
-	}
-}
-
-func (k *Kafka) ProducerMessage(topic string, data []byte) error {
+func newProducerConfig() *sarama.Config {
 	config := sarama.NewConfig()
+	config.Version = sarama.V2_1_0_0
 	config.Producer.Return.Successes = true
 	config.Producer.RequiredAcks = sarama.WaitForAll
-	config.Producer.Retry.Max = configApp.Config.Kafka.MaxRetry
-	producer, err := sarama.NewSyncProducer(k.brokers, config)
+	config.Producer.Idempotent = true
+	config.Producer.Retry.Max = max(configApp.Config.Kafka.ProducerMaxRetry, 1)
+	config.Net.MaxOpenRequests = 1
+	if configApp.Config.Kafka.TimeOutInMS > 0 {
+		timeout := time.Duration(configApp.Config.Kafka.TimeOutInMS) * time.Millisecond
+		config.Producer.Timeout = timeout
+		config.Net.DialTimeout = timeout
+		config.Net.ReadTimeout = timeout
+		config.Net.WriteTimeout = timeout
+	}
+
+	return config
+}
+
+func NewSyncProducer(brokers []string) (sarama.SyncProducer, error) {
+	producer, err := sarama.NewSyncProducer(brokers, newProducerConfig())
 	if err != nil {
 		logrus.Errorf("failed create producer: %v", err)
-		return err
+		return nil, err
 	}
-	defer func(producer sarama.SyncProducer) {
-		err = producer.Close()
-		if err != nil {
-			logrus.Errorf("failed close producer: %v", err)
-			return
-		}
-	}(producer)
+
+	return producer, nil
+}
+
+func NewKafkaProducer(producer sarama.SyncProducer) *Kafka {
+	return &Kafka{
+		producer: producer,
+	}
+}
+
+func (k *Kafka) ProducerMessage(topic, key string, data []byte, headers map[string]string) error {
+	producedAt := time.Now()
+	recordHeaders := make([]sarama.RecordHeader, 0, len(headers)+1)
+	for name, value := range headers {
+		recordHeaders = append(recordHeaders, sarama.RecordHeader{
+			Key:   []byte(name),
+			Value: []byte(value),
+		})
+	}
+	recordHeaders = append(recordHeaders, sarama.RecordHeader{
+		Key:   []byte(constants.KafkaHeaderProducedAt),
+		Value: []byte(producedAt.Format(time.RFC3339Nano)),
+	})
 
 	message := &sarama.ProducerMessage{
-		Topic:   topic,
-		Headers: nil,
-		Value:   sarama.ByteEncoder(data),
+		Topic:     topic,
+		Headers:   recordHeaders,
+		Value:     sarama.ByteEncoder(data),
+		Timestamp: producedAt,
+	}
+	if key != "" {
+		message.Key = sarama.StringEncoder(key)
 	}
 
-	partition, offset, err := producer.SendMessage(message)
+	partition, offset, err := k.producer.SendMessage(message)
 	if err != nil {
 		logrus.Errorf("failed send message: %v", err)
 		return err
@@ -53,3 +85,13 @@ func (k *Kafka) ProducerMessage(topic string, data []byte) error {
 	logrus.Infof("message is stored in topic(%s)/partition(%d)/offset(%d)\n", topic, partition, offset)
 	return nil
 }
+
+func (k *Kafka) Close() error {
+	err := k.producer.Close()
+	if err != nil {
+		logrus.Errorf("failed close producer: %v", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package kafka
+
+import (
+	"errors"
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	configApp "payment-service/config"
+	"payment-service/constants"
+	"testing"
+	"time"
+)
+
+func withKafkaConfig(t *testing.T, kafka configApp.Kafka) {
+	t.Helper()
+
+	previous := configApp.Config.Kafka
+	configApp.Config.Kafka = kafka
+	t.Cleanup(func() {
+		configApp.Config.Kafka = previous
+	})
+}
+
+func TestNewProducerConfigRetry(t *testing.T) {
+	tests := []struct {
+		name             string
+		maxRetry         int
+		producerMaxRetry int
+		want             int
+	}{
+		{name: "unset", want: 1},
+		{name: "negative", producerMaxRetry: -3, want: 1},
+		{name: "configured", producerMaxRetry: 5, want: 5},
+		{name: "consumer retry ignored", maxRetry: 10, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withKafkaConfig(t, configApp.Kafka{MaxRetry: tt.maxRetry, ProducerMaxRetry: tt.producerMaxRetry})
+
+			config := newProducerConfig()
+			if config.Producer.Retry.Max != tt.want {
+				t.Errorf("Retry.Max = %d, want %d", config.Producer.Retry.Max, tt.want)
+			}
+			if err := config.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestNewProducerConfigTimeout(t *testing.T) {
+	withKafkaConfig(t, configApp.Kafka{TimeOutInMS: 1500})
+
+	config := newProducerConfig()
+	if config.Producer.Timeout != 1500*time.Millisecond || config.Net.DialTimeout != 1500*time.Millisecond {
+		t.Errorf("timeouts = %s/%s, want 1.5s", config.Producer.Timeout, config.Net.DialTimeout)
+	}
+	if !config.Producer.Idempotent || config.Producer.RequiredAcks != sarama.WaitForAll || config.Net.MaxOpenRequests != 1 {
+		t.Errorf("producer is not configured for idempotent delivery")
+	}
+}
+
+func TestProducerMessage(t *testing.T) {
+	withKafkaConfig(t, configApp.Kafka{})
+
+	producer := mocks.NewSyncProducer(t, newProducerConfig())
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(message *sarama.ProducerMessage) error {
+		if message.Topic != "payment-service-callback" {
+			return errors.New("unexpected topic " + message.Topic)
+		}
+
+		key, _ := message.Key.Encode()
+		if string(key) != "order-1" {
+			return errors.New("unexpected key " + string(key))
+		}
+
+		value, _ := message.Value.Encode()
+		if string(value) != `{"status":"settlement"}` {
+			return errors.New("unexpected value " + string(value))
+		}
+
+		headers := map[string]string{}
+		for _, header := range message.Headers {
+			headers[string(header.Key)] = string(header.Value)
+		}
+		if headers["x-correlation-id"] != "correlation-1" {
+			return errors.New("missing correlation header")
+		}
+		if _, err := time.Parse(time.RFC3339Nano, headers[constants.KafkaHeaderProducedAt]); err != nil {
+			return errors.New("missing produced-at header")
+		}
+
+		return nil
+	})
+
+	kafka := NewKafkaProducer(producer)
+	err := kafka.ProducerMessage("payment-service-callback", "order-1", []byte(`{"status":"settlement"}`), map[string]string{
+		"x-correlation-id": "correlation-1",
+	})
+	if err != nil {
+		t.Fatalf("ProducerMessage() error = %v", err)
+	}
+
+	if err = kafka.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
+
+func TestProducerMessageWithoutKey(t *testing.T) {
+	withKafkaConfig(t, configApp.Kafka{})
+
+	producer := mocks.NewSyncProducer(t, newProducerConfig())
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(message *sarama.ProducerMessage) error {
+		if message.Key != nil {
+			return errors.New("key should be empty")
+		}
+		return nil
+	})
+
+	kafka := NewKafkaProducer(producer)
+	if err := kafka.ProducerMessage("payment-service-callback", "", []byte(`{}`), nil); err != nil {
+		t.Fatalf("ProducerMessage() error = %v", err)
+	}
+
+	_ = kafka.Close()
+}
+
+func TestProducerMessageFailure(t *testing.T) {
+	withKafkaConfig(t, configApp.Kafka{})
+
+	producer := mocks.NewSyncProducer(t, newProducerConfig())
+	producer.ExpectSendMessageAndFail(sarama.ErrNotEnoughReplicas)
+
+	kafka := NewKafkaProducer(producer)
+	err := kafka.ProducerMessage("payment-service-callback", "order-1", []byte(`{}`), nil)
+	if !errors.Is(err, sarama.ErrNotEnoughReplicas) {
+		t.Fatalf("error = %v, want %v", err, sarama.ErrNotEnoughReplicas)
+	}
+
+	_ = kafka.Close()
+}
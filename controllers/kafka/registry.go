@@ -1,17 +1,22 @@
 package kafka
 
 type Registry struct {
-	brokers []string
+	producer IKafka
 }
 
 type IKafkaRegistry interface {
 	GetKafkaProducer() IKafka
+	Close() error
 }
 
-func NewKafkaRegistry(brokers []string) *Registry {
-	return &Registry{brokers}
+func NewKafkaRegistry(producer IKafka) *Registry {
+	return &Registry{producer: producer}
 }
 
 func (r *Registry) GetKafkaProducer() IKafka {
-	return NewKafkaProducer(r.brokers)
+	return r.producer
+}
+
+func (r *Registry) Close() error {
+	return r.producer.Close()
 }
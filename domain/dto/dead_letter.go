@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"payment-service/constants"
+	"time"
+)
+
+type DeadLetterRequest struct {
+	Source     constants.DeadLetterSource `json:"source"`
+	Topic      string                     `json:"topic"`
+	MessageKey *string                    `json:"messageKey"`
+	Headers    map[string]string          `json:"headers"`
+	Payload    []byte                     `json:"payload"`
+	Error      string                     `json:"error"`
+	Attempts   int                        `json:"attempts"`
+}
+
+type DeadLetterResponse struct {
+	UUID       uuid.UUID                  `json:"uuid"`
+	Source     constants.DeadLetterSource `json:"source"`
+	Topic      string                     `json:"topic"`
+	MessageKey *string                    `json:"messageKey,omitempty"`
+	Headers    map[string]string          `json:"headers"`
+	Payload    string                     `json:"payload"`
+	Error      string                     `json:"error"`
+	Attempts   int                        `json:"attempts"`
+	Status     constants.DeadLetterStatus `json:"status"`
+	RedrivenAt *time.Time                 `json:"redrivenAt,omitempty"`
+	CreatedAt  time.Time                  `json:"createdAt"`
+}
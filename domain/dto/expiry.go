@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+type ExpireOverdueRequest struct {
+	ExpiredBefore time.Time
+	Limit         int
+}
+
+type ExpireOverdueReport struct {
+	Scanned int `json:"scanned"`
+	Expired int `json:"expired"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+}
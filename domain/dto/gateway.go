@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"net/http"
+	"payment-service/common/money"
+	"payment-service/constants"
+)
+
+type GatewayPaymentLink struct {
+	Token         string  `json:"token"`
+	RedirectURL   string  `json:"redirectURL"`
+	TransactionID *string `json:"transactionID"`
+}
+
+type GatewayTransaction struct {
+	Provider       constants.PaymentProvider     `json:"provider"`
+	OrderID        uuid.UUID                     `json:"orderID"`
+	GatewayOrderID string                        `json:"gatewayOrderID"`
+	TransactionID  string                        `json:"transactionID"`
+	Status         constants.PaymentStatusString `json:"status"`
+	StatusCode     string                        `json:"statusCode"`
+	PaymentType    string                        `json:"paymentType"`
+	VANumber       *string                       `json:"vaNumber"`
+	Bank           *string                       `json:"bank"`
+	Acquirer       *string                       `json:"acquirer"`
+	GrossAmount    string                        `json:"grossAmount"`
+	Currency       string                        `json:"currency"`
+	RefundKey      *string                       `json:"refundKey"`
+	RawPayload     []byte                        `json:"-"`
+}
+
+type GatewayRefundRequest struct {
+	RefundKey string      `json:"refundKey"`
+	Amount    money.Money `json:"amount"`
+	Reason    string      `json:"reason"`
+}
+
+type GatewayRefundResponse struct {
+	RefundKey string      `json:"refundKey"`
+	Amount    money.Money `json:"amount"`
+	Status    string      `json:"status"`
+}
+
+type WebhookRequest struct {
+	Provider constants.PaymentProvider `json:"provider"`
+	Header   http.Header               `json:"-"`
+	Body     []byte                    `json:"-"`
+}
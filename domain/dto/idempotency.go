@@ -0,0 +1,14 @@
+package dto
+
+import "github.com/google/uuid"
+
+type IdempotencyRequest struct {
+	UserUUID    uuid.UUID `json:"userUUID"`
+	Key         string    `json:"key"`
+	RequestHash string    `json:"requestHash"`
+}
+
+type IdempotencyResponse struct {
+	StatusCode int    `json:"statusCode"`
+	Body       []byte `json:"body"`
+}
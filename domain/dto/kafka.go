@@ -2,6 +2,7 @@ package dto
 
 import (
 	"github.com/google/uuid"
+	"payment-service/common/money"
 	"time"
 )
 
@@ -15,11 +16,12 @@ type KafkaMetaData struct {
 }
 
 type KafkaData struct {
-	OrderID   uuid.UUID  `json:"orderID"`
-	PaymentID uuid.UUID  `json:"paymentID"`
-	Status    string     `json:"status"`
-	ExpiredAt time.Time  `json:"expiredAt"`
-	PaidAt    *time.Time `json:"paidAt"`
+	OrderID   uuid.UUID   `json:"orderID"`
+	PaymentID uuid.UUID   `json:"paymentID"`
+	Status    string      `json:"status"`
+	Amount    money.Money `json:"amount"`
+	ExpiredAt time.Time   `json:"expiredAt"`
+	PaidAt    *time.Time  `json:"paidAt"`
 }
 
 type KafkaBody struct {
@@ -32,3 +34,14 @@ type KafkaMessage struct {
 	Metadata KafkaMetaData `json:"metadata"`
 	Body     KafkaBody     `json:"body"`
 }
+
+type KafkaMessageV2 struct {
+	EventID       uuid.UUID  `json:"eventID"`
+	EventName     string     `json:"eventName"`
+	Version       int        `json:"version"`
+	OccurredAt    time.Time  `json:"occurredAt"`
+	AggregateID   uuid.UUID  `json:"aggregateID"`
+	AggregateType string     `json:"aggregateType"`
+	Producer      string     `json:"producer"`
+	Data          *KafkaData `json:"data"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+import "github.com/google/uuid"
+
+type OrderKafkaData struct {
+	OrderID uuid.UUID `json:"orderID"`
+	Status  string    `json:"status"`
+}
+
+type OrderKafkaBody struct {
+	Type string          `json:"type"`
+	Data *OrderKafkaData `json:"data"`
+}
+
+type OrderKafkaMessage struct {
+	Event    KafkaEvent     `json:"event"`
+	Metadata KafkaMetaData  `json:"metadata"`
+	Body     OrderKafkaBody `json:"body"`
+}
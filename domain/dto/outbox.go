@@ -0,0 +1,9 @@
+package dto
+
+type OutboxRequest struct {
+	AggregateID string            `json:"aggregateID"`
+	Topic       string            `json:"topic"`
+	EventName   string            `json:"eventName"`
+	Payload     []byte            `json:"payload"`
+	Headers     map[string]string `json:"headers"`
+}
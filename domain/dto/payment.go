@@ -2,18 +2,21 @@ package dto
 
 import (
 	"github.com/google/uuid"
+	"payment-service/common/money"
 	"payment-service/constants"
 	"time"
 )
 
 type PaymentRequest struct {
-	PaymentLink    string          `json:"paymentLink"`
-	OrderID        string          `json:"orderID"`
-	ExpiredAt      time.Time       `json:"expiredAt"`
-	Amount         float64         `json:"amount"`
-	Description    *string         `json:"description"`
-	CustomerDetail *CustomerDetail `json:"customerDetail"`
-	ItemDetails    []ItemDetail    `json:"itemDetails"`
+	Provider       *constants.PaymentProvider `json:"provider"`
+	UserID         *uuid.UUID                 `json:"-"`
+	PaymentLink    string                     `json:"paymentLink"`
+	OrderID        string                     `json:"orderID"`
+	ExpiredAt      time.Time                  `json:"expiredAt"`
+	Amount         money.Money                `json:"amount"`
+	Description    *string                    `json:"description"`
+	CustomerDetail *CustomerDetail            `json:"customerDetail"`
+	ItemDetails    []ItemDetail               `json:"itemDetails" validate:"required,min=1,dive"`
 }
 
 type CustomerDetail struct {
@@ -23,17 +26,42 @@ type CustomerDetail struct {
 }
 
 type ItemDetail struct {
-	ID       string  `json:"id"`
-	Amount   float64 `json:"amount"`
-	Name     string  `json:"name"`
-	Quantity int     `json:"quantity"`
+	ID       string      `json:"id" validate:"required"`
+	Amount   money.Money `json:"amount"`
+	Name     string      `json:"name" validate:"required"`
+	Quantity int         `json:"quantity" validate:"required,gt=0"`
+}
+
+type PaymentItemResponse struct {
+	ID       string      `json:"id"`
+	Name     string      `json:"name"`
+	Amount   money.Money `json:"amount"`
+	Quantity int         `json:"quantity"`
 }
 
 type PaymentRequestParam struct {
-	Page       int     `form:"page" validate:"required"`
-	Limit      int     `form:"limit" validate:"required"`
-	SortColumn *string `form:"sortColumn"`
-	SortOrder  *string `form:"sortOrder"`
+	Pagination    string                          `form:"pagination" validate:"omitempty,oneof=offset cursor"`
+	Page          int                             `form:"page" validate:"required_unless=Pagination cursor,min=0"`
+	Cursor        *string                         `form:"cursor"`
+	Limit         int                             `form:"limit" validate:"required,min=1,max=100"`
+	SortColumn    *string                         `form:"sortColumn"`
+	SortOrder     *string                         `form:"sortOrder"`
+	Status        []constants.PaymentStatusString `form:"status" validate:"omitempty,dive,oneof=initial pending authorize challenge capture settlement expire cancel deny failure refund partial_refund"`
+	OrderID       *string                         `form:"orderID" validate:"omitempty,uuid"`
+	TransactionID *string                         `form:"transactionID"`
+	Bank          *string                         `form:"bank"`
+	VANumber      *string                         `form:"vaNumber"`
+	MinAmount     *money.Money                    `form:"minAmount"`
+	MaxAmount     *money.Money                    `form:"maxAmount"`
+	CreatedFrom   *time.Time                      `form:"createdFrom"`
+	CreatedTo     *time.Time                      `form:"createdTo"`
+	UpdatedFrom   *time.Time                      `form:"updatedFrom"`
+	UpdatedTo     *time.Time                      `form:"updatedTo"`
+	PaidFrom      *time.Time                      `form:"paidFrom"`
+	PaidTo        *time.Time                      `form:"paidTo"`
+	ExpiredFrom   *time.Time                      `form:"expiredFrom"`
+	ExpiredTo     *time.Time                      `form:"expiredTo"`
+	Search        *string                         `form:"search" validate:"omitempty,max=100"`
 }
 
 type UpdatePaymentRequest struct {
@@ -44,12 +72,15 @@ type UpdatePaymentRequest struct {
 	Bank          *string                  `json:"bank"`
 	InvoiceLink   *string                  `json:"invoiceLink,omitempty"`
 	Acquirer      *string                  `json:"acquirer"`
+	PaymentLink   *string                  `json:"paymentLink,omitempty"`
+	ExpiredAt     *time.Time               `json:"expiredAt,omitempty"`
 }
 
 type PaymentResponse struct {
 	UUID          uuid.UUID                     `json:"uuid"`
 	OrderID       uuid.UUID                     `json:"orderID"`
-	Amount        float64                       `json:"amount"`
+	Provider      constants.PaymentProvider     `json:"provider"`
+	Amount        money.Money                   `json:"amount"`
 	Status        constants.PaymentStatusString `json:"status"`
 	PaymentLink   string                        `json:"paymentLink"`
 	InvoiceLink   *string                       `json:"invoiceLink,omitempty"`
@@ -62,6 +93,8 @@ type PaymentResponse struct {
 	ExpiredAt     *time.Time                    `json:"expiredAt"`
 	CreatedAt     *time.Time                    `json:"createdAt"`
 	UpdatedAt     *time.Time                    `json:"updatedAt"`
+	ActiveAttempt *PaymentAttemptResponse       `json:"activeAttempt,omitempty"`
+	Items         []PaymentItemResponse         `json:"items,omitempty"`
 }
 
 type WebHook struct {
@@ -75,12 +108,19 @@ type WebHook struct {
 	SettlementTime    string                        `json:"settlement_time"`
 	PaymentType       string                        `json:"payment_type"`
 	PaymentAmount     []PaymentAmount               `json:"payment_amount"`
-	OrderID           uuid.UUID                     `json:"order_id"`
+	OrderID           string                        `json:"order_id"`
 	MerchantID        string                        `json:"merchant_id"`
 	GrossAmount       string                        `json:"gross_amount"`
-	FraudStatus       string                        `json:"fraud_status"`
+	FraudStatus       constants.FraudStatus         `json:"fraud_status"`
 	Currency          string                        `json:"currency"`
 	Acquirer          *string                       `json:"acquirer"`
+	Refunds           []WebHookRefund               `json:"refunds"`
+}
+
+type WebHookRefund struct {
+	RefundKey    string `json:"refund_key"`
+	RefundAmount string `json:"refund_amount"`
+	Reason       string `json:"reason"`
 }
 
 type VANumber struct {
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"payment-service/constants"
+	"time"
+)
+
+type PaymentAttemptRequest struct {
+	PaymentID      uint       `json:"paymentID"`
+	GatewayOrderID string     `json:"gatewayOrderID"`
+	Sequence       int        `json:"sequence"`
+	PaymentLink    string     `json:"paymentLink"`
+	ExpiredAt      *time.Time `json:"expiredAt"`
+}
+
+type PaymentAttemptResponse struct {
+	UUID           uuid.UUID                      `json:"uuid"`
+	GatewayOrderID string                         `json:"gatewayOrderID"`
+	Sequence       int                            `json:"sequence"`
+	PaymentLink    string                         `json:"paymentLink"`
+	Status         constants.PaymentAttemptStatus `json:"status"`
+	ExpiredAt      *time.Time                     `json:"expiredAt"`
+	CreatedAt      time.Time                      `json:"createdAt"`
+}
+
+type RenewPaymentRequest struct {
+	ExpiredAt      time.Time       `json:"expiredAt" validate:"required"`
+	CustomerDetail *CustomerDetail `json:"customerDetail"`
+	ItemDetails    []ItemDetail    `json:"itemDetails" validate:"omitempty,dive"`
+}
@@ -3,6 +3,7 @@ package dto
 import "payment-service/constants"
 
 type PaymentHistoryRequest struct {
-	PaymentID uint                          `json:"paymentID"`
-	Status    constants.PaymentStatusString `json:"status"`
+	PaymentID   uint                          `json:"paymentID"`
+	Status      constants.PaymentStatusString `json:"status"`
+	Description *string                       `json:"description"`
 }
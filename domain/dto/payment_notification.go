@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"payment-service/constants"
+	"time"
+)
+
+type PaymentNotificationRequest struct {
+	Provider          constants.PaymentProvider     `json:"provider"`
+	OrderID           uuid.UUID                     `json:"orderID"`
+	TransactionID     string                        `json:"transactionID"`
+	TransactionStatus constants.PaymentStatusString `json:"transactionStatus"`
+	StatusCode        string                        `json:"statusCode"`
+	RefundKey         string                        `json:"refundKey"`
+	Payload           []byte                        `json:"payload"`
+}
+
+type PaymentNotificationRequestParam struct {
+	Page          int     `form:"page" validate:"required,min=1"`
+	Limit         int     `form:"limit" validate:"required,min=1,max=100"`
+	OrderID       *string `form:"orderID"`
+	TransactionID *string `form:"transactionID"`
+}
+
+type PaymentNotificationResponse struct {
+	Provider          constants.PaymentProvider     `json:"provider"`
+	OrderID           uuid.UUID                     `json:"orderID"`
+	TransactionID     string                        `json:"transactionID"`
+	TransactionStatus constants.PaymentStatusString `json:"transactionStatus"`
+	StatusCode        string                        `json:"statusCode"`
+	Payload           json.RawMessage               `json:"payload"`
+	CreatedAt         time.Time                     `json:"createdAt"`
+}
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"payment-service/common/money"
+	"payment-service/constants"
+	"time"
+)
+
+type PaymentReviewRequest struct {
+	PaymentID         uint                          `json:"paymentID"`
+	OrderID           uuid.UUID                     `json:"orderID"`
+	TransactionID     string                        `json:"transactionID"`
+	TransactionStatus constants.PaymentStatusString `json:"transactionStatus"`
+	ExpectedAmount    money.Money                   `json:"expectedAmount"`
+	NotifiedAmount    string                        `json:"notifiedAmount"`
+	NotifiedCurrency  string                        `json:"notifiedCurrency"`
+	Reason            string                        `json:"reason"`
+}
+
+type PaymentReviewRequestParam struct {
+	Page   int                            `form:"page" validate:"required,min=1"`
+	Limit  int                            `form:"limit" validate:"required,min=1,max=100"`
+	Status *constants.PaymentReviewStatus `form:"status"`
+}
+
+type ResolvePaymentReviewRequest struct {
+	Status constants.PaymentReviewStatus `json:"status" validate:"required,oneof=approved rejected"`
+	Note   *string                       `json:"note"`
+}
+
+type PaymentReviewResponse struct {
+	UUID              uuid.UUID                     `json:"uuid"`
+	OrderID           uuid.UUID                     `json:"orderID"`
+	TransactionID     string                        `json:"transactionID"`
+	TransactionStatus constants.PaymentStatusString `json:"transactionStatus"`
+	ExpectedAmount    money.Money                   `json:"expectedAmount"`
+	NotifiedAmount    string                        `json:"notifiedAmount"`
+	NotifiedCurrency  string                        `json:"notifiedCurrency"`
+	Reason            string                        `json:"reason"`
+	Status            constants.PaymentReviewStatus `json:"status"`
+	Note              *string                       `json:"note,omitempty"`
+	ResolvedAt        *time.Time                    `json:"resolvedAt,omitempty"`
+	CreatedAt         time.Time                     `json:"createdAt"`
+}
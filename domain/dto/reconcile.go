@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"payment-service/constants"
+	"time"
+)
+
+type ReconcileRequest struct {
+	UpdatedBefore time.Time
+	Limit         int
+}
+
+type ReconcileDiscrepancy struct {
+	PaymentUUID   uuid.UUID                     `json:"paymentUUID"`
+	OrderID       uuid.UUID                     `json:"orderID"`
+	Provider      constants.PaymentProvider     `json:"provider"`
+	LocalStatus   constants.PaymentStatusString `json:"localStatus"`
+	GatewayStatus constants.PaymentStatusString `json:"gatewayStatus,omitempty"`
+	Action        constants.ReconcileAction     `json:"action"`
+	Error         *string                       `json:"error,omitempty"`
+}
+
+type ReconcileReport struct {
+	StartedAt     time.Time              `json:"startedAt"`
+	FinishedAt    time.Time              `json:"finishedAt"`
+	Scanned       int                    `json:"scanned"`
+	InSync        int                    `json:"inSync"`
+	Applied       int                    `json:"applied"`
+	Rejected      int                    `json:"rejected"`
+	NotFound      int                    `json:"notFound"`
+	Failed        int                    `json:"failed"`
+	Discrepancies []ReconcileDiscrepancy `json:"discrepancies"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"payment-service/common/money"
+	"payment-service/constants"
+	"time"
+)
+
+type RefundRequest struct {
+	Amount money.Money `json:"amount"`
+	Reason string      `json:"reason" validate:"required"`
+}
+
+type CreateRefundRequest struct {
+	PaymentID uint        `json:"paymentID"`
+	Amount    money.Money `json:"amount"`
+	Reason    string      `json:"reason"`
+}
+
+type RefundResponse struct {
+	UUID        uuid.UUID              `json:"uuid"`
+	PaymentUUID uuid.UUID              `json:"paymentUUID"`
+	OrderID     uuid.UUID              `json:"orderID"`
+	RefundKey   string                 `json:"refundKey"`
+	Amount      money.Money            `json:"amount"`
+	Reason      string                 `json:"reason"`
+	Status      constants.RefundStatus `json:"status"`
+	CreatedAt   time.Time              `json:"createdAt"`
+	UpdatedAt   time.Time              `json:"updatedAt"`
+}
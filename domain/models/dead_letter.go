@@ -0,0 +1,23 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"payment-service/constants"
+	"time"
+)
+
+type DeadLetter struct {
+	ID         uint                       `gorm:"primaryKey;autoIncrement"`
+	UUID       uuid.UUID                  `gorm:"type:uuid;not null;uniqueIndex"`
+	Source     constants.DeadLetterSource `gorm:"type:varchar(20);not null"`
+	Topic      string                     `gorm:"type:varchar(255);not null"`
+	MessageKey *string                    `gorm:"type:varchar(255);default:null"`
+	Headers    string                     `gorm:"type:jsonb;not null;default:'{}'"`
+	Payload    string                     `gorm:"type:text;not null"`
+	Error      string                     `gorm:"type:text;not null"`
+	Attempts   int                        `gorm:"not null"`
+	Status     constants.DeadLetterStatus `gorm:"type:varchar(20);not null;index"`
+	RedrivenAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
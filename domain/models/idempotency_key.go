@@ -0,0 +1,19 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"payment-service/constants"
+	"time"
+)
+
+type IdempotencyKey struct {
+	ID           uint                        `gorm:"primaryKey;autoIncrement"`
+	UserUUID     uuid.UUID                   `gorm:"type:uuid;not null;uniqueIndex:idx_idempotency_keys_user_key"`
+	Key          string                      `gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_keys_user_key"`
+	RequestHash  string                      `gorm:"type:varchar(64);not null"`
+	Status       constants.IdempotencyStatus `gorm:"type:varchar(20);not null"`
+	StatusCode   *int                        `gorm:"default:null"`
+	ResponseBody *string                     `gorm:"type:text;default:null"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"payment-service/constants"
+	"time"
+)
+
+type Outbox struct {
+	ID            uint                   `gorm:"primaryKey;autoIncrement"`
+	UUID          uuid.UUID              `gorm:"type:uuid;not null"`
+	AggregateID   string                 `gorm:"type:varchar(100);not null;index"`
+	Topic         string                 `gorm:"type:varchar(255);not null"`
+	EventName     string                 `gorm:"type:varchar(100);not null"`
+	Payload       string                 `gorm:"type:jsonb;not null"`
+	Headers       string                 `gorm:"type:jsonb;not null;default:'{}'"`
+	Status        constants.OutboxStatus `gorm:"type:varchar(20);not null;index"`
+	Attempts      int                    `gorm:"not null;default:0"`
+	LastError     *string                `gorm:"type:text;default:null"`
+	NextAttemptAt time.Time              `gorm:"not null"`
+	PublishedAt   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
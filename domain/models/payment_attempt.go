@@ -0,0 +1,20 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"payment-service/constants"
+	"time"
+)
+
+type PaymentAttempt struct {
+	ID             uint                           `gorm:"primaryKey;autoIncrement"`
+	UUID           uuid.UUID                      `gorm:"type:uuid;not null"`
+	PaymentID      uint                           `gorm:"not null;index"`
+	GatewayOrderID string                         `gorm:"type:varchar(100);not null;uniqueIndex"`
+	Sequence       int                            `gorm:"not null"`
+	PaymentLink    string                         `gorm:"type:varchar(255);not null"`
+	Status         constants.PaymentAttemptStatus `gorm:"type:varchar(20);not null"`
+	ExpiredAt      *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
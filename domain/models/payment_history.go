@@ -6,9 +6,10 @@ import (
 )
 
 type PaymentHistory struct {
-	ID        uint                          `gorm:"primaryKey;autoIncrement"`
-	PaymentID uint                          `gorm:"bigint;autoIncrement"`
-	Status    constants.PaymentStatusString `gorm:"type:varchar(255);not null"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID          uint                          `gorm:"primaryKey;autoIncrement"`
+	PaymentID   uint                          `gorm:"bigint;autoIncrement"`
+	Status      constants.PaymentStatusString `gorm:"type:varchar(255);not null"`
+	Description *string                       `gorm:"type:text;default:null"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
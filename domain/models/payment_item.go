@@ -0,0 +1,17 @@
+package models
+
+import (
+	"payment-service/common/money"
+	"time"
+)
+
+type PaymentItem struct {
+	ID        uint        `gorm:"primaryKey;autoIncrement"`
+	PaymentID uint        `gorm:"not null;index"`
+	ItemID    string      `gorm:"type:varchar(100);not null"`
+	Name      string      `gorm:"type:varchar(255);not null"`
+	Price     money.Money `gorm:"embedded;embeddedPrefix:price_"`
+	Quantity  int         `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
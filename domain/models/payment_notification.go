@@ -0,0 +1,19 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"payment-service/constants"
+	"time"
+)
+
+type PaymentNotification struct {
+	ID                uint                          `gorm:"primaryKey;autoIncrement"`
+	Provider          constants.PaymentProvider     `gorm:"type:varchar(20);not null;default:'midtrans'"`
+	OrderID           uuid.UUID                     `gorm:"type:uuid;not null;index"`
+	TransactionID     string                        `gorm:"type:varchar(100);not null;uniqueIndex:idx_payment_notifications_dedupe"`
+	TransactionStatus constants.PaymentStatusString `gorm:"type:varchar(50);not null;uniqueIndex:idx_payment_notifications_dedupe"`
+	StatusCode        string                        `gorm:"type:varchar(10);not null;uniqueIndex:idx_payment_notifications_dedupe"`
+	RefundKey         string                        `gorm:"type:varchar(100);not null;default:'';uniqueIndex:idx_payment_notifications_dedupe"`
+	Payload           string                        `gorm:"type:jsonb;not null"`
+	CreatedAt         time.Time
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"payment-service/common/money"
+	"payment-service/constants"
+	"time"
+)
+
+type PaymentReview struct {
+	ID                uint                          `gorm:"primaryKey;autoIncrement"`
+	UUID              uuid.UUID                     `gorm:"type:uuid;not null"`
+	PaymentID         uint                          `gorm:"not null;index"`
+	OrderID           uuid.UUID                     `gorm:"type:uuid;not null;index"`
+	TransactionID     string                        `gorm:"type:varchar(100);not null"`
+	TransactionStatus constants.PaymentStatusString `gorm:"type:varchar(50);not null"`
+	ExpectedAmount    money.Money                   `gorm:"embedded;embeddedPrefix:expected_amount_"`
+	NotifiedAmount    string                        `gorm:"type:varchar(50);not null"`
+	NotifiedCurrency  string                        `gorm:"type:varchar(3);not null"`
+	Reason            string                        `gorm:"type:text;not null"`
+	Status            constants.PaymentReviewStatus `gorm:"type:varchar(20);not null;index"`
+	Note              *string                       `gorm:"type:text;default:null"`
+	ResolvedAt        *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"payment-service/common/money"
+	"payment-service/constants"
+	"time"
+)
+
+type Refund struct {
+	ID        uint                   `gorm:"primaryKey;autoIncrement"`
+	UUID      uuid.UUID              `gorm:"type:uuid;not null"`
+	PaymentID uint                   `gorm:"not null;index"`
+	RefundKey string                 `gorm:"type:varchar(100);not null;uniqueIndex"`
+	Amount    money.Money            `gorm:"embedded;embeddedPrefix:amount_"`
+	Reason    string                 `gorm:"type:text;not null"`
+	Status    constants.RefundStatus `gorm:"type:varchar(20);not null"`
+	Error     *string                `gorm:"type:text;default:null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
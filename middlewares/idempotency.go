@@ -0,0 +1,111 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	userClient "payment-service/clients/user"
+	"payment-service/common/response"
+	"payment-service/constants"
+	errConstant "payment-service/constants/error"
+	errIdempotency "payment-service/constants/error/idempotency"
+	"payment-service/domain/dto"
+	idempotencyService "payment-service/services/idempotency"
+)
+
+type bodyWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *bodyWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyWriter) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
+
+func requestHash(c *gin.Context, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Request.Method))
+	hash.Write([]byte(c.FullPath()))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func Idempotency(service idempotencyService.IIdempotencyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(constants.IdempotencyKey)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		user, ok := c.Request.Context().Value(constants.User).(*userClient.UserData)
+		if !ok {
+			responseUnauthorized(c, errConstant.ErrUnauthorized.Error())
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, response.Response{
+				Status:  constants.Error,
+				Message: err.Error(),
+			})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		request := &dto.IdempotencyRequest{
+			UserUUID:    user.UUID,
+			Key:         key,
+			RequestHash: requestHash(c, body),
+		}
+		replay, err := service.Begin(c.Request.Context(), request)
+		if err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, errIdempotency.ErrIdempotencyKeyReused) ||
+				errors.Is(err, errIdempotency.ErrIdempotencyRequestInProgress) {
+				code = http.StatusConflict
+			}
+			c.AbortWithStatusJSON(code, response.Response{
+				Status:  constants.Error,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		if replay != nil {
+			c.Header(constants.IdempotentReplayed, "true")
+			c.Data(replay.StatusCode, "application/json; charset=utf-8", replay.Body)
+			c.Abort()
+			return
+		}
+
+		writer := &bodyWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = writer
+		c.Next()
+
+		status := writer.Status()
+		if status >= http.StatusOK && status < http.StatusMultipleChoices {
+			err = service.Complete(c.Request.Context(), request, &dto.IdempotencyResponse{
+				StatusCode: status,
+				Body:       writer.body.Bytes(),
+			})
+		} else {
+			err = service.Release(c.Request.Context(), request)
+		}
+		if err != nil {
+			logrus.Errorf("failed store idempotency key %s: %v", key, err)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/didip/tollbooth"
 	"github.com/didip/tollbooth/limiter"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"net/http"
 	"payment-service/clients"
@@ -34,6 +35,20 @@ func HandlePanic() gin.HandlerFunc {
 	}
 }
 
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(constants.XRequestID)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+
+		c.Set(constants.RequestID, requestID)
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), constants.RequestID, requestID))
+		c.Writer.Header().Set(constants.XRequestID, requestID)
+		c.Next()
+	}
+}
+
 func RateLimiter(lmt *limiter.Limiter) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		err := tollbooth.LimitByRequest(lmt, c.Writer, c.Request)
@@ -103,6 +118,9 @@ func CheckRole(roles []string, client clients.IClientRegistry) gin.HandlerFunc {
 			responseUnauthorized(c, errConstant.ErrUnauthorized.Error())
 			return
 		}
+
+		c.Set(constants.User, user)
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), constants.User, user))
 		c.Next()
 	}
 }
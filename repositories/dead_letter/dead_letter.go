@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	errWrap "payment-service/common/error"
+	"payment-service/constants"
+	errConstant "payment-service/constants/error"
+	errDeadLetter "payment-service/constants/error/dead_letter"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+	"time"
+)
+
+type DeadLetterRepository struct {
+	db *gorm.DB
+}
+
+type IDeadLetterRepository interface {
+	FindAll(context.Context, *constants.DeadLetterStatus) ([]models.DeadLetter, error)
+	FindByUUID(context.Context, string) (*models.DeadLetter, error)
+	Create(context.Context, *gorm.DB, *dto.DeadLetterRequest) (*models.DeadLetter, error)
+	MarkRedriven(context.Context, uint) error
+}
+
+func NewDeadLetterRepository(db *gorm.DB) IDeadLetterRepository {
+	return &DeadLetterRepository{db: db}
+}
+
+func (d *DeadLetterRepository) FindAll(ctx context.Context, status *constants.DeadLetterStatus) ([]models.DeadLetter, error) {
+	var deadLetters []models.DeadLetter
+	query := d.db.WithContext(ctx)
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+
+	err := query.Order("id asc").Find(&deadLetters).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return deadLetters, nil
+}
+
+func (d *DeadLetterRepository) FindByUUID(ctx context.Context, uuid string) (*models.DeadLetter, error) {
+	var deadLetter models.DeadLetter
+	err := d.db.
+		WithContext(ctx).
+		Where("uuid = ?", uuid).
+		First(&deadLetter).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errDeadLetter.ErrDeadLetterNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &deadLetter, nil
+}
+
+func (d *DeadLetterRepository) Create(ctx context.Context, tx *gorm.DB, request *dto.DeadLetterRequest) (*models.DeadLetter, error) {
+	headers, err := json.Marshal(request.Headers)
+	if err != nil {
+		return nil, errWrap.WrapError(err)
+	}
+
+	deadLetter := models.DeadLetter{
+		UUID:       uuid.New(),
+		Source:     request.Source,
+		Topic:      request.Topic,
+		MessageKey: request.MessageKey,
+		Headers:    string(headers),
+		Payload:    string(request.Payload),
+		Error:      request.Error,
+		Attempts:   request.Attempts,
+		Status:     constants.DeadLetterDead,
+	}
+
+	err = tx.WithContext(ctx).Create(&deadLetter).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &deadLetter, nil
+}
+
+func (d *DeadLetterRepository) MarkRedriven(ctx context.Context, id uint) error {
+	now := time.Now()
+	err := d.db.
+		WithContext(ctx).
+		Model(&models.DeadLetter{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":      constants.DeadLetterRedriven,
+			"redriven_at": &now,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
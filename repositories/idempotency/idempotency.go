@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	errWrap "payment-service/common/error"
+	"payment-service/constants"
+	errConstant "payment-service/constants/error"
+	errIdempotency "payment-service/constants/error/idempotency"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+	"time"
+)
+
+type IdempotencyRepository struct {
+	db *gorm.DB
+}
+
+type IIdempotencyRepository interface {
+	FindByKey(context.Context, *dto.IdempotencyRequest) (*models.IdempotencyKey, error)
+	Create(context.Context, *dto.IdempotencyRequest) (bool, error)
+	TakeOver(context.Context, *dto.IdempotencyRequest, time.Time) (bool, error)
+	Complete(context.Context, *dto.IdempotencyRequest, *dto.IdempotencyResponse) error
+	Delete(context.Context, *dto.IdempotencyRequest) error
+}
+
+func NewIdempotencyRepository(db *gorm.DB) IIdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+func (i *IdempotencyRepository) FindByKey(ctx context.Context, request *dto.IdempotencyRequest) (*models.IdempotencyKey, error) {
+	var idempotencyKey models.IdempotencyKey
+	err := i.db.
+		WithContext(ctx).
+		Where("user_uuid = ? AND key = ?", request.UserUUID, request.Key).
+		First(&idempotencyKey).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errIdempotency.ErrIdempotencyKeyNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &idempotencyKey, nil
+}
+
+func (i *IdempotencyRepository) Create(ctx context.Context, request *dto.IdempotencyRequest) (bool, error) {
+	idempotencyKey := models.IdempotencyKey{
+		UserUUID:    request.UserUUID,
+		Key:         request.Key,
+		RequestHash: request.RequestHash,
+		Status:      constants.IdempotencyProcessing,
+	}
+
+	result := i.db.
+		WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&idempotencyKey)
+	if result.Error != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (i *IdempotencyRepository) TakeOver(ctx context.Context, request *dto.IdempotencyRequest, staleBefore time.Time) (bool, error) {
+	result := i.db.
+		WithContext(ctx).
+		Model(&models.IdempotencyKey{}).
+		Where("user_uuid = ? AND key = ?", request.UserUUID, request.Key).
+		Where("status = ?", constants.IdempotencyProcessing).
+		Where("updated_at < ?", staleBefore).
+		Update("updated_at", time.Now())
+	if result.Error != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (i *IdempotencyRepository) Complete(ctx context.Context, request *dto.IdempotencyRequest, response *dto.IdempotencyResponse) error {
+	body := string(response.Body)
+	err := i.db.
+		WithContext(ctx).
+		Model(&models.IdempotencyKey{}).
+		Where("user_uuid = ? AND key = ?", request.UserUUID, request.Key).
+		Updates(map[string]any{
+			"status":        constants.IdempotencyCompleted,
+			"status_code":   response.StatusCode,
+			"response_body": body,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (i *IdempotencyRepository) Delete(ctx context.Context, request *dto.IdempotencyRequest) error {
+	err := i.db.
+		WithContext(ctx).
+		Where("user_uuid = ? AND key = ?", request.UserUUID, request.Key).
+		Where("status = ?", constants.IdempotencyProcessing).
+		Delete(&models.IdempotencyKey{}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"database/sql/driver"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	errWrap "payment-service/common/error"
+	errConstant "payment-service/constants/error"
+)
+
+type LockRepository struct {
+	db *gorm.DB
+}
+
+type ILockRepository interface {
+	TryAcquire(context.Context, *gorm.DB, int64) (bool, error)
+	TryAcquireSession(context.Context, int64) (func(), bool, error)
+}
+
+func NewLockRepository(db *gorm.DB) ILockRepository {
+	return &LockRepository{db: db}
+}
+
+func (l *LockRepository) TryAcquire(ctx context.Context, tx *gorm.DB, key int64) (bool, error) {
+	var acquired bool
+	err := tx.
+		WithContext(ctx).
+		Raw("SELECT pg_try_advisory_xact_lock(?)", key).
+		Scan(&acquired).
+		Error
+	if err != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return acquired, nil
+}
+
+// TryAcquireSession holds the lock on a dedicated connection outside any
+// transaction, so long running workers do not keep a transaction open.
+// The returned release must be called once the work is done.
+func (l *LockRepository) TryAcquireSession(ctx context.Context, key int64) (func(), bool, error) {
+	sqlDB, err := l.db.DB()
+	if err != nil {
+		return nil, false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	var acquired bool
+	err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired)
+	if err != nil || !acquired {
+		_ = conn.Close()
+		if err != nil {
+			return nil, false, errWrap.WrapError(errConstant.ErrSQLError)
+		}
+		return nil, false, nil
+	}
+
+	release := func() {
+		_, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key)
+		if err != nil {
+			logrus.Errorf("failed release advisory lock %d, discarding connection: %v", key, err)
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+		_ = conn.Close()
+	}
+
+	return release, true, nil
+}
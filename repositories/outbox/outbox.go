@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	errWrap "payment-service/common/error"
+	"payment-service/constants"
+	errConstant "payment-service/constants/error"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+	"time"
+)
+
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+type IOutboxRepository interface {
+	FindPending(context.Context, *gorm.DB, int) ([]models.Outbox, error)
+	CountPending(context.Context) (int64, error)
+	Create(context.Context, *gorm.DB, *dto.OutboxRequest) (*models.Outbox, error)
+	MarkPublished(context.Context, *gorm.DB, uint) error
+	MarkRetry(context.Context, *gorm.DB, *models.Outbox, error, time.Time) error
+	MarkFailed(context.Context, *gorm.DB, *models.Outbox, error) error
+}
+
+func NewOutboxRepository(db *gorm.DB) IOutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+func (o *OutboxRepository) FindPending(ctx context.Context, tx *gorm.DB, limit int) ([]models.Outbox, error) {
+	var outboxes []models.Outbox
+	err := tx.
+		WithContext(ctx).
+		Where("status = ?", constants.OutboxPending).
+		Order("id asc").
+		Limit(limit).
+		Find(&outboxes).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return outboxes, nil
+}
+
+func (o *OutboxRepository) CountPending(ctx context.Context) (int64, error) {
+	var total int64
+	err := o.db.
+		WithContext(ctx).
+		Model(&models.Outbox{}).
+		Where("status = ?", constants.OutboxPending).
+		Count(&total).
+		Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return total, nil
+}
+
+func (o *OutboxRepository) Create(ctx context.Context, tx *gorm.DB, request *dto.OutboxRequest) (*models.Outbox, error) {
+	headers, err := json.Marshal(request.Headers)
+	if err != nil {
+		return nil, errWrap.WrapError(err)
+	}
+
+	outbox := models.Outbox{
+		UUID:          uuid.New(),
+		AggregateID:   request.AggregateID,
+		Topic:         request.Topic,
+		EventName:     request.EventName,
+		Payload:       string(request.Payload),
+		Headers:       string(headers),
+		Status:        constants.OutboxPending,
+		NextAttemptAt: time.Now(),
+	}
+
+	err = tx.WithContext(ctx).Create(&outbox).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &outbox, nil
+}
+
+func (o *OutboxRepository) MarkPublished(ctx context.Context, tx *gorm.DB, id uint) error {
+	now := time.Now()
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Outbox{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":       constants.OutboxPublished,
+			"published_at": &now,
+			"last_error":   nil,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (o *OutboxRepository) MarkRetry(ctx context.Context, tx *gorm.DB, outbox *models.Outbox, cause error, nextAttemptAt time.Time) error {
+	lastError := cause.Error()
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Outbox{}).
+		Where("id = ?", outbox.ID).
+		Updates(map[string]any{
+			"attempts":        outbox.Attempts + 1,
+			"last_error":      &lastError,
+			"next_attempt_at": nextAttemptAt,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (o *OutboxRepository) MarkFailed(ctx context.Context, tx *gorm.DB, outbox *models.Outbox, cause error) error {
+	lastError := cause.Error()
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Outbox{}).
+		Where("id = ?", outbox.ID).
+		Updates(map[string]any{
+			"status":     constants.OutboxFailed,
+			"attempts":   outbox.Attempts + 1,
+			"last_error": &lastError,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
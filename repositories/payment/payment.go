@@ -6,14 +6,34 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	errWrap "payment-service/common/error"
+	"payment-service/common/queryoption"
 	"payment-service/constants"
 	errConstant "payment-service/constants/error"
 	errPayment "payment-service/constants/error/payment"
 	"payment-service/domain/dto"
 	"payment-service/domain/models"
+	"slices"
+	"strings"
+	"time"
 )
 
+var paymentSortable = queryoption.NewSortable(
+	queryoption.Sort{Column: "created_at", Direction: queryoption.Desc},
+	"id",
+	map[string]string{
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+		"paid_at":    "paid_at",
+		"expired_at": "expired_at",
+		"amount":     "amount_minor",
+		"status":     "status",
+	},
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 type PaymentRepository struct {
 	db *gorm.DB
 }
@@ -22,8 +42,13 @@ type IPaymentRepository interface {
 	FindAllWithPagination(context.Context, *dto.PaymentRequestParam) ([]models.Payment, int64, error)
 	FindByUUID(context.Context, string) (*models.Payment, error)
 	FindByOrderID(context.Context, string) (*models.Payment, error)
+	FindByUUIDForUpdate(context.Context, *gorm.DB, string) (*models.Payment, error)
+	FindByOrderIDForUpdate(context.Context, *gorm.DB, string) (*models.Payment, error)
+	FindAllWithCursor(context.Context, *dto.PaymentRequestParam, string, *queryoption.Cursor, ...string) ([]models.Payment, bool, error)
+	FindAllOverdue(context.Context, []constants.PaymentStatus, time.Time, int) ([]models.Payment, error)
 	Create(context.Context, *gorm.DB, *dto.PaymentRequest) (*models.Payment, error)
 	Update(context.Context, *gorm.DB, string, *dto.UpdatePaymentRequest) (*models.Payment, error)
+	ResetForRenewal(context.Context, *gorm.DB, uint, string, time.Time) error
 }
 
 func NewPaymentRepository(db *gorm.DB) IPaymentRepository {
@@ -35,32 +60,29 @@ func NewPaymentRepository(db *gorm.DB) IPaymentRepository {
 func (p *PaymentRepository) FindAllWithPagination(ctx context.Context, param *dto.PaymentRequestParam) ([]models.Payment, int64, error) {
 	var (
 		payments []models.Payment
-		sort     string
 		total    int64
 	)
 
-	if param.SortColumn != nil {
-		sort = fmt.Sprintf("%s %s", *param.SortColumn, *param.SortOrder)
-	} else {
-		sort = "created_at desc"
+	sort, err := paymentSortable.Resolve(param.SortColumn, param.SortOrder)
+	if err != nil {
+		return nil, 0, errWrap.WrapError(err)
 	}
 
 	limit := param.Limit
-	offset := (param.Page) - 1*limit
-	err := p.db.
-		WithContext(ctx).
+	offset := queryoption.Offset(param.Page, limit)
+	err = p.applyFilters(p.db.WithContext(ctx), param).
+		Preload("Items").
 		Limit(limit).
 		Offset(offset).
-		Order(sort).
+		Order(paymentSortable.OrderBy(sort)).
 		Find(&payments).
 		Error
 	if err != nil {
 		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
 	}
 
-	err = p.db.
-		WithContext(ctx).
-		Model(&payments).
+	err = p.applyFilters(p.db.WithContext(ctx), param).
+		Model(&models.Payment{}).
 		Count(&total).
 		Error
 	if err != nil {
@@ -70,10 +92,83 @@ func (p *PaymentRepository) FindAllWithPagination(ctx context.Context, param *dt
 	return payments, total, nil
 }
 
+func (p *PaymentRepository) applyFilters(query *gorm.DB, param *dto.PaymentRequestParam) *gorm.DB {
+	if len(param.Status) > 0 {
+		statuses := make([]constants.PaymentStatus, 0, len(param.Status))
+		for _, status := range param.Status {
+			statuses = append(statuses, status.GetStatusInt())
+		}
+		query = query.Where("status IN ?", statuses)
+	}
+
+	if param.OrderID != nil {
+		query = query.Where("order_id = ?", *param.OrderID)
+	}
+
+	if param.TransactionID != nil {
+		query = query.Where("transaction_id = ?", *param.TransactionID)
+	}
+
+	if param.Bank != nil {
+		query = query.Where("LOWER(bank) = LOWER(?)", *param.Bank)
+	}
+
+	if param.VANumber != nil {
+		query = query.Where("va_number = ?", *param.VANumber)
+	}
+
+	if param.MinAmount != nil {
+		query = query.Where("amount_currency = ? AND amount_minor >= ?", param.MinAmount.Currency, param.MinAmount.Minor)
+	}
+
+	if param.MaxAmount != nil {
+		query = query.Where("amount_currency = ? AND amount_minor <= ?", param.MaxAmount.Currency, param.MaxAmount.Minor)
+	}
+
+	if param.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *param.CreatedFrom)
+	}
+
+	if param.CreatedTo != nil {
+		query = query.Where("created_at <= ?", *param.CreatedTo)
+	}
+
+	if param.UpdatedFrom != nil {
+		query = query.Where("updated_at >= ?", *param.UpdatedFrom)
+	}
+
+	if param.UpdatedTo != nil {
+		query = query.Where("updated_at <= ?", *param.UpdatedTo)
+	}
+
+	if param.PaidFrom != nil {
+		query = query.Where("paid_at >= ?", *param.PaidFrom)
+	}
+
+	if param.PaidTo != nil {
+		query = query.Where("paid_at <= ?", *param.PaidTo)
+	}
+
+	if param.ExpiredFrom != nil {
+		query = query.Where("expired_at >= ?", *param.ExpiredFrom)
+	}
+
+	if param.ExpiredTo != nil {
+		query = query.Where("expired_at <= ?", *param.ExpiredTo)
+	}
+
+	if param.Search != nil && *param.Search != "" {
+		query = query.Where("description ILIKE ?", "%"+likeEscaper.Replace(*param.Search)+"%")
+	}
+
+	return query
+}
+
 func (p *PaymentRepository) FindByUUID(ctx context.Context, uuid string) (*models.Payment, error) {
 	var payment models.Payment
 	err := p.db.
 		WithContext(ctx).
+		Preload("Items").
 		Where("uuid = ?", uuid).
 		First(&payment).
 		Error
@@ -87,6 +182,42 @@ func (p *PaymentRepository) FindByUUID(ctx context.Context, uuid string) (*model
 	return &payment, nil
 }
 
+func (p *PaymentRepository) FindByUUIDForUpdate(ctx context.Context, tx *gorm.DB, uuid string) (*models.Payment, error) {
+	var payment models.Payment
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("uuid = ?", uuid).
+		First(&payment).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errPayment.ErrPaymentNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &payment, nil
+}
+
+func (p *PaymentRepository) FindByOrderIDForUpdate(ctx context.Context, tx *gorm.DB, orderID string) (*models.Payment, error) {
+	var payment models.Payment
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ?", orderID).
+		First(&payment).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errPayment.ErrPaymentNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &payment, nil
+}
+
 func (p *PaymentRepository) FindByOrderID(ctx context.Context, orderID string) (*models.Payment, error) {
 	var payment models.Payment
 	err := p.db.
@@ -104,13 +235,80 @@ func (p *PaymentRepository) FindByOrderID(ctx context.Context, orderID string) (
 	return &payment, nil
 }
 
+func (p *PaymentRepository) FindAllWithCursor(
+	ctx context.Context,
+	param *dto.PaymentRequestParam,
+	direction string,
+	cursor *queryoption.Cursor,
+	preloads ...string,
+) ([]models.Payment, bool, error) {
+	var payments []models.Payment
+
+	scanDirection := direction
+	query := p.applyFilters(p.db.WithContext(ctx), param)
+	for _, preload := range preloads {
+		query = query.Preload(preload)
+	}
+	if cursor != nil {
+		scanDirection = cursor.ScanDirection()
+		query = query.Where(
+			fmt.Sprintf("(created_at, id) %s (?, ?)", queryoption.KeysetOperator(scanDirection)),
+			cursor.CreatedAt,
+			cursor.ID,
+		)
+	}
+
+	err := query.
+		Order(paymentSortable.OrderBy(queryoption.Sort{Column: "created_at", Direction: scanDirection})).
+		Limit(param.Limit + 1).
+		Find(&payments).
+		Error
+	if err != nil {
+		return nil, false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	hasMore := len(payments) > param.Limit
+	if hasMore {
+		payments = payments[:param.Limit]
+	}
+
+	if cursor != nil && cursor.Backward {
+		slices.Reverse(payments)
+	}
+
+	return payments, hasMore, nil
+}
+
+func (p *PaymentRepository) FindAllOverdue(ctx context.Context, statuses []constants.PaymentStatus, expiredBefore time.Time, limit int) ([]models.Payment, error) {
+	var payments []models.Payment
+	err := p.db.
+		WithContext(ctx).
+		Where("status IN ?", statuses).
+		Where("expired_at < ?", expiredBefore).
+		Order("expired_at asc").
+		Limit(limit).
+		Find(&payments).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return payments, nil
+}
+
 func (p *PaymentRepository) Create(ctx context.Context, tx *gorm.DB, request *dto.PaymentRequest) (*models.Payment, error) {
 	status := constants.Initial
 	orderID := uuid.MustParse(request.OrderID)
+	provider := constants.ProviderMidtrans
+	if request.Provider != nil {
+		provider = *request.Provider
+	}
 
 	payment := models.Payment{
 		UUID:        uuid.New(),
 		OrderID:     orderID,
+		UserID:      request.UserID,
+		Provider:    provider,
 		Amount:      request.Amount,
 		PaymentLink: request.PaymentLink,
 		ExpiredAt:   &request.ExpiredAt,
@@ -118,15 +316,51 @@ func (p *PaymentRepository) Create(ctx context.Context, tx *gorm.DB, request *dt
 		Status:      &status,
 	}
 
-	err := tx.WithContext(ctx).Create(&payment).Error
-	if err != nil {
+	result := tx.
+		WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "order_id"}}, DoNothing: true}).
+		Create(&payment)
+	if result.Error != nil {
 		return nil, errWrap.WrapError(errConstant.ErrSQLError)
 	}
 
+	if result.RowsAffected == 0 {
+		return nil, errWrap.WrapError(errPayment.ErrPaymentAlreadyExists)
+	}
+
 	return &payment, nil
 }
 
+func (p *PaymentRepository) lockStatus(ctx context.Context, tx *gorm.DB, query string, arg any) (*constants.PaymentStatus, error) {
+	var current models.Payment
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(query, arg).
+		First(&current).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errPayment.ErrPaymentNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return current.Status, nil
+}
+
 func (p *PaymentRepository) Update(ctx context.Context, tx *gorm.DB, orderID string, request *dto.UpdatePaymentRequest) (*models.Payment, error) {
+	if request.Status != nil {
+		current, err := p.lockStatus(ctx, tx, "order_id = ?", orderID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !current.CanTransitionTo(*request.Status) {
+			return nil, errWrap.WrapError(errPayment.ErrInvalidStatusTransition)
+		}
+	}
+
 	payment := models.Payment{
 		Status:        request.Status,
 		TransactionID: request.TransactionID,
@@ -135,6 +369,10 @@ func (p *PaymentRepository) Update(ctx context.Context, tx *gorm.DB, orderID str
 		VANumber:      request.VANumber,
 		Bank:          request.Bank,
 		Acquirer:      request.Acquirer,
+		ExpiredAt:     request.ExpiredAt,
+	}
+	if request.PaymentLink != nil {
+		payment.PaymentLink = *request.PaymentLink
 	}
 
 	err := tx.WithContext(ctx).Where("order_id = ?", orderID).Updates(&payment).Error
@@ -144,3 +382,30 @@ func (p *PaymentRepository) Update(ctx context.Context, tx *gorm.DB, orderID str
 
 	return &payment, nil
 }
+
+func (p *PaymentRepository) ResetForRenewal(ctx context.Context, tx *gorm.DB, id uint, paymentLink string, expiredAt time.Time) error {
+	current, err := p.lockStatus(ctx, tx, "id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	if !current.CanRenew() {
+		return errWrap.WrapError(errPayment.ErrInvalidStatusTransition)
+	}
+
+	err = tx.
+		WithContext(ctx).
+		Model(&models.Payment{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":       constants.Initial,
+			"payment_link": paymentLink,
+			"expired_at":   expiredAt,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	errWrap "payment-service/common/error"
+	"payment-service/constants"
+	errConstant "payment-service/constants/error"
+	errPayment "payment-service/constants/error/payment"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+)
+
+type PaymentAttemptRepository struct {
+	db *gorm.DB
+}
+
+type IPaymentAttemptRepository interface {
+	FindActiveByPaymentID(context.Context, uint) (*models.PaymentAttempt, error)
+	FindByGatewayOrderID(context.Context, string) (*models.PaymentAttempt, error)
+	CountByPaymentID(context.Context, *gorm.DB, uint) (int64, error)
+	Create(context.Context, *gorm.DB, *dto.PaymentAttemptRequest) (*models.PaymentAttempt, error)
+	SupersedeByPaymentID(context.Context, *gorm.DB, uint) error
+}
+
+func NewPaymentAttemptRepository(db *gorm.DB) IPaymentAttemptRepository {
+	return &PaymentAttemptRepository{db: db}
+}
+
+func (p *PaymentAttemptRepository) find(ctx context.Context, query *gorm.DB) (*models.PaymentAttempt, error) {
+	var attempt models.PaymentAttempt
+	err := query.WithContext(ctx).First(&attempt).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errPayment.ErrPaymentAttemptNotFound
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &attempt, nil
+}
+
+func (p *PaymentAttemptRepository) FindActiveByPaymentID(ctx context.Context, paymentID uint) (*models.PaymentAttempt, error) {
+	return p.find(ctx, p.db.
+		Where("payment_id = ?", paymentID).
+		Where("status = ?", constants.PaymentAttemptActive).
+		Order("sequence desc"))
+}
+
+func (p *PaymentAttemptRepository) FindByGatewayOrderID(ctx context.Context, gatewayOrderID string) (*models.PaymentAttempt, error) {
+	return p.find(ctx, p.db.Where("gateway_order_id = ?", gatewayOrderID))
+}
+
+func (p *PaymentAttemptRepository) CountByPaymentID(ctx context.Context, tx *gorm.DB, paymentID uint) (int64, error) {
+	var total int64
+	err := tx.
+		WithContext(ctx).
+		Model(&models.PaymentAttempt{}).
+		Where("payment_id = ?", paymentID).
+		Count(&total).
+		Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return total, nil
+}
+
+func (p *PaymentAttemptRepository) Create(ctx context.Context, tx *gorm.DB, request *dto.PaymentAttemptRequest) (*models.PaymentAttempt, error) {
+	attempt := models.PaymentAttempt{
+		UUID:           uuid.New(),
+		PaymentID:      request.PaymentID,
+		GatewayOrderID: request.GatewayOrderID,
+		Sequence:       request.Sequence,
+		PaymentLink:    request.PaymentLink,
+		Status:         constants.PaymentAttemptActive,
+		ExpiredAt:      request.ExpiredAt,
+	}
+
+	err := tx.WithContext(ctx).Create(&attempt).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &attempt, nil
+}
+
+func (p *PaymentAttemptRepository) SupersedeByPaymentID(ctx context.Context, tx *gorm.DB, paymentID uint) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.PaymentAttempt{}).
+		Where("payment_id = ?", paymentID).
+		Where("status = ?", constants.PaymentAttemptActive).
+		Update("status", constants.PaymentAttemptSuperseded).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
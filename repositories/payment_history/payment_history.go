@@ -23,8 +23,9 @@ func NewPaymentHistoryRepository(db *gorm.DB) IPaymentHistoryRepository {
 
 func (p *PaymentHistoryRepository) Create(ctx context.Context, tx *gorm.DB, request *dto.PaymentHistoryRequest) error {
 	paymentHistory := models.PaymentHistory{
-		PaymentID: request.PaymentID,
-		Status:    request.Status,
+		PaymentID:   request.PaymentID,
+		Status:      request.Status,
+		Description: request.Description,
 	}
 
 	err := tx.
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"gorm.io/gorm"
+	errWrap "payment-service/common/error"
+	errConstant "payment-service/constants/error"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+)
+
+type PaymentItemRepository struct {
+	db *gorm.DB
+}
+
+type IPaymentItemRepository interface {
+	FindByPaymentID(context.Context, uint) ([]models.PaymentItem, error)
+	CreateBatch(context.Context, *gorm.DB, uint, []dto.ItemDetail) ([]models.PaymentItem, error)
+	ReplaceByPaymentID(context.Context, *gorm.DB, uint, []dto.ItemDetail) ([]models.PaymentItem, error)
+}
+
+func NewPaymentItemRepository(db *gorm.DB) IPaymentItemRepository {
+	return &PaymentItemRepository{db: db}
+}
+
+func (p *PaymentItemRepository) FindByPaymentID(ctx context.Context, paymentID uint) ([]models.PaymentItem, error) {
+	var items []models.PaymentItem
+	err := p.db.
+		WithContext(ctx).
+		Where("payment_id = ?", paymentID).
+		Order("id asc").
+		Find(&items).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return items, nil
+}
+
+func (p *PaymentItemRepository) CreateBatch(ctx context.Context, tx *gorm.DB, paymentID uint, requests []dto.ItemDetail) ([]models.PaymentItem, error) {
+	if len(requests) == 0 {
+		return nil, nil
+	}
+
+	items := make([]models.PaymentItem, 0, len(requests))
+	for _, request := range requests {
+		items = append(items, models.PaymentItem{
+			PaymentID: paymentID,
+			ItemID:    request.ID,
+			Name:      request.Name,
+			Price:     request.Amount,
+			Quantity:  request.Quantity,
+		})
+	}
+
+	err := tx.WithContext(ctx).Create(&items).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return items, nil
+}
+
+func (p *PaymentItemRepository) ReplaceByPaymentID(ctx context.Context, tx *gorm.DB, paymentID uint, requests []dto.ItemDetail) ([]models.PaymentItem, error) {
+	err := tx.WithContext(ctx).Where("payment_id = ?", paymentID).Delete(&models.PaymentItem{}).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return p.CreateBatch(ctx, tx, paymentID, requests)
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	errWrap "payment-service/common/error"
+	"payment-service/common/queryoption"
+	errConstant "payment-service/constants/error"
+	errPayment "payment-service/constants/error/payment"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+)
+
+type PaymentNotificationRepository struct {
+	db *gorm.DB
+}
+
+type IPaymentNotificationRepository interface {
+	FindAllWithPagination(context.Context, *dto.PaymentNotificationRequestParam) ([]models.PaymentNotification, int64, error)
+	Create(context.Context, *gorm.DB, *dto.PaymentNotificationRequest) (*models.PaymentNotification, error)
+}
+
+func NewPaymentNotificationRepository(db *gorm.DB) IPaymentNotificationRepository {
+	return &PaymentNotificationRepository{db: db}
+}
+
+func (p *PaymentNotificationRepository) FindAllWithPagination(ctx context.Context, param *dto.PaymentNotificationRequestParam) ([]models.PaymentNotification, int64, error) {
+	var (
+		notifications []models.PaymentNotification
+		total         int64
+	)
+
+	query := p.db.WithContext(ctx).Model(&models.PaymentNotification{})
+	if param.OrderID != nil {
+		query = query.Where("order_id = ?", *param.OrderID)
+	}
+
+	if param.TransactionID != nil {
+		query = query.Where("transaction_id = ?", *param.TransactionID)
+	}
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	limit := param.Limit
+	offset := queryoption.Offset(param.Page, limit)
+	err = query.
+		Limit(limit).
+		Offset(offset).
+		Order("created_at desc").
+		Find(&notifications).
+		Error
+	if err != nil {
+		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return notifications, total, nil
+}
+
+func (p *PaymentNotificationRepository) Create(ctx context.Context, tx *gorm.DB, request *dto.PaymentNotificationRequest) (*models.PaymentNotification, error) {
+	notification := models.PaymentNotification{
+		Provider:          request.Provider,
+		OrderID:           request.OrderID,
+		TransactionID:     request.TransactionID,
+		TransactionStatus: request.TransactionStatus,
+		StatusCode:        request.StatusCode,
+		RefundKey:         request.RefundKey,
+		Payload:           string(request.Payload),
+	}
+
+	result := tx.
+		WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&notification)
+	if result.Error != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, errPayment.ErrNotificationAlreadyProcessed
+	}
+
+	return &notification, nil
+}
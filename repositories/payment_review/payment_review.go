@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	errWrap "payment-service/common/error"
+	"payment-service/common/queryoption"
+	"payment-service/constants"
+	errConstant "payment-service/constants/error"
+	errPayment "payment-service/constants/error/payment"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+	"time"
+)
+
+type PaymentReviewRepository struct {
+	db *gorm.DB
+}
+
+type IPaymentReviewRepository interface {
+	FindAllWithPagination(context.Context, *dto.PaymentReviewRequestParam) ([]models.PaymentReview, int64, error)
+	FindByUUID(context.Context, string) (*models.PaymentReview, error)
+	HasOpenByPaymentID(context.Context, *gorm.DB, uint) (bool, error)
+	Create(context.Context, *gorm.DB, *dto.PaymentReviewRequest) (*models.PaymentReview, error)
+	Resolve(context.Context, *gorm.DB, uint, *dto.ResolvePaymentReviewRequest) error
+}
+
+func NewPaymentReviewRepository(db *gorm.DB) IPaymentReviewRepository {
+	return &PaymentReviewRepository{db: db}
+}
+
+func (p *PaymentReviewRepository) FindAllWithPagination(ctx context.Context, param *dto.PaymentReviewRequestParam) ([]models.PaymentReview, int64, error) {
+	var (
+		reviews []models.PaymentReview
+		total   int64
+	)
+
+	query := p.db.WithContext(ctx).Model(&models.PaymentReview{})
+	if param.Status != nil {
+		query = query.Where("status = ?", *param.Status)
+	}
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	limit := param.Limit
+	offset := queryoption.Offset(param.Page, limit)
+	err = query.
+		Limit(limit).
+		Offset(offset).
+		Order("created_at desc").
+		Find(&reviews).
+		Error
+	if err != nil {
+		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return reviews, total, nil
+}
+
+func (p *PaymentReviewRepository) FindByUUID(ctx context.Context, uuid string) (*models.PaymentReview, error) {
+	var review models.PaymentReview
+	err := p.db.
+		WithContext(ctx).
+		Where("uuid = ?", uuid).
+		First(&review).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errPayment.ErrPaymentReviewNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &review, nil
+}
+
+func (p *PaymentReviewRepository) HasOpenByPaymentID(ctx context.Context, tx *gorm.DB, paymentID uint) (bool, error) {
+	var total int64
+	err := tx.
+		WithContext(ctx).
+		Model(&models.PaymentReview{}).
+		Where("payment_id = ?", paymentID).
+		Where("status = ?", constants.PaymentReviewOpen).
+		Count(&total).
+		Error
+	if err != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return total > 0, nil
+}
+
+func (p *PaymentReviewRepository) Create(ctx context.Context, tx *gorm.DB, request *dto.PaymentReviewRequest) (*models.PaymentReview, error) {
+	review := models.PaymentReview{
+		UUID:              uuid.New(),
+		PaymentID:         request.PaymentID,
+		OrderID:           request.OrderID,
+		TransactionID:     request.TransactionID,
+		TransactionStatus: request.TransactionStatus,
+		ExpectedAmount:    request.ExpectedAmount,
+		NotifiedAmount:    request.NotifiedAmount,
+		NotifiedCurrency:  request.NotifiedCurrency,
+		Reason:            request.Reason,
+		Status:            constants.PaymentReviewOpen,
+	}
+
+	err := tx.WithContext(ctx).Create(&review).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &review, nil
+}
+
+func (p *PaymentReviewRepository) Resolve(ctx context.Context, tx *gorm.DB, id uint, request *dto.ResolvePaymentReviewRequest) error {
+	result := tx.
+		WithContext(ctx).
+		Model(&models.PaymentReview{}).
+		Where("id = ?", id).
+		Where("status = ?", constants.PaymentReviewOpen).
+		Updates(map[string]any{
+			"status":      request.Status,
+			"note":        request.Note,
+			"resolved_at": time.Now(),
+		})
+	if result.Error != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if result.RowsAffected == 0 {
+		return errWrap.WrapError(errPayment.ErrPaymentReviewAlreadyResolved)
+	}
+
+	return nil
+}
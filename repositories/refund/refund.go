@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	errWrap "payment-service/common/error"
+	"payment-service/constants"
+	errConstant "payment-service/constants/error"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+)
+
+type RefundRepository struct {
+	db *gorm.DB
+}
+
+type IRefundRepository interface {
+	SumAmountByPaymentID(context.Context, *gorm.DB, uint) (int64, error)
+	Create(context.Context, *gorm.DB, *dto.CreateRefundRequest) (*models.Refund, error)
+	MarkFailed(context.Context, uint, error) error
+	MarkSucceeded(context.Context, *gorm.DB, uint, *string) error
+}
+
+func NewRefundRepository(db *gorm.DB) IRefundRepository {
+	return &RefundRepository{db: db}
+}
+
+func (r *RefundRepository) SumAmountByPaymentID(ctx context.Context, tx *gorm.DB, paymentID uint) (int64, error) {
+	var total int64
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Refund{}).
+		Select("COALESCE(SUM(amount_minor), 0)").
+		Where("payment_id = ?", paymentID).
+		Where("status <> ?", constants.RefundFailed).
+		Scan(&total).
+		Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return total, nil
+}
+
+func (r *RefundRepository) Create(ctx context.Context, tx *gorm.DB, request *dto.CreateRefundRequest) (*models.Refund, error) {
+	refundUUID := uuid.New()
+	refund := models.Refund{
+		UUID:      refundUUID,
+		PaymentID: request.PaymentID,
+		RefundKey: refundUUID.String(),
+		Amount:    request.Amount,
+		Reason:    request.Reason,
+		Status:    constants.RefundPending,
+	}
+
+	err := tx.WithContext(ctx).Create(&refund).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &refund, nil
+}
+
+func (r *RefundRepository) MarkFailed(ctx context.Context, id uint, cause error) error {
+	message := cause.Error()
+	err := r.db.
+		WithContext(ctx).
+		Model(&models.Refund{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status": constants.RefundFailed,
+			"error":  message,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (r *RefundRepository) MarkSucceeded(ctx context.Context, tx *gorm.DB, paymentID uint, refundKey *string) error {
+	query := tx.
+		WithContext(ctx).
+		Model(&models.Refund{}).
+		Where("payment_id = ?", paymentID).
+		Where("status = ?", constants.RefundPending)
+	if refundKey != nil {
+		query = query.Where("refund_key = ?", *refundKey)
+	}
+
+	err := query.Update("status", constants.RefundSucceeded).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -2,8 +2,17 @@ package repositories
 
 import (
 	"gorm.io/gorm"
+	deadLetterRepository "payment-service/repositories/dead_letter"
+	idempotencyRepository "payment-service/repositories/idempotency"
+	lockRepository "payment-service/repositories/lock"
+	outboxRepository "payment-service/repositories/outbox"
 	paymentRepository "payment-service/repositories/payment"
+	paymentAttemptRepository "payment-service/repositories/payment_attempt"
 	paymentHistoryRepository "payment-service/repositories/payment_history"
+	paymentItemRepository "payment-service/repositories/payment_item"
+	paymentNotificationRepository "payment-service/repositories/payment_notification"
+	paymentReviewRepository "payment-service/repositories/payment_review"
+	refundRepository "payment-service/repositories/refund"
 )
 
 type Registry struct {
@@ -13,6 +22,15 @@ type Registry struct {
 type IRepositoryRegistry interface {
 	GetPayment() paymentRepository.IPaymentRepository
 	GetPaymentHistory() paymentHistoryRepository.IPaymentHistoryRepository
+	GetPaymentNotification() paymentNotificationRepository.IPaymentNotificationRepository
+	GetOutbox() outboxRepository.IOutboxRepository
+	GetLock() lockRepository.ILockRepository
+	GetDeadLetter() deadLetterRepository.IDeadLetterRepository
+	GetRefund() refundRepository.IRefundRepository
+	GetIdempotency() idempotencyRepository.IIdempotencyRepository
+	GetPaymentAttempt() paymentAttemptRepository.IPaymentAttemptRepository
+	GetPaymentItem() paymentItemRepository.IPaymentItemRepository
+	GetPaymentReview() paymentReviewRepository.IPaymentReviewRepository
 	GetTx() *gorm.DB
 }
 
@@ -30,6 +48,42 @@ func (r *Registry) GetPaymentHistory() paymentHistoryRepository.IPaymentHistoryR
 	return paymentHistoryRepository.NewPaymentHistoryRepository(r.db)
 }
 
+func (r *Registry) GetPaymentNotification() paymentNotificationRepository.IPaymentNotificationRepository {
+	return paymentNotificationRepository.NewPaymentNotificationRepository(r.db)
+}
+
+func (r *Registry) GetOutbox() outboxRepository.IOutboxRepository {
+	return outboxRepository.NewOutboxRepository(r.db)
+}
+
+func (r *Registry) GetLock() lockRepository.ILockRepository {
+	return lockRepository.NewLockRepository(r.db)
+}
+
+func (r *Registry) GetDeadLetter() deadLetterRepository.IDeadLetterRepository {
+	return deadLetterRepository.NewDeadLetterRepository(r.db)
+}
+
+func (r *Registry) GetRefund() refundRepository.IRefundRepository {
+	return refundRepository.NewRefundRepository(r.db)
+}
+
+func (r *Registry) GetIdempotency() idempotencyRepository.IIdempotencyRepository {
+	return idempotencyRepository.NewIdempotencyRepository(r.db)
+}
+
+func (r *Registry) GetPaymentAttempt() paymentAttemptRepository.IPaymentAttemptRepository {
+	return paymentAttemptRepository.NewPaymentAttemptRepository(r.db)
+}
+
+func (r *Registry) GetPaymentItem() paymentItemRepository.IPaymentItemRepository {
+	return paymentItemRepository.NewPaymentItemRepository(r.db)
+}
+
+func (r *Registry) GetPaymentReview() paymentReviewRepository.IPaymentReviewRepository {
+	return paymentReviewRepository.NewPaymentReviewRepository(r.db)
+}
+
 func (r *Registry) GetTx() *gorm.DB {
 	return r.db
 }
@@ -6,11 +6,13 @@ import (
 	"payment-service/constants"
 	controllers "payment-service/controllers/http"
 	"payment-service/middlewares"
+	"payment-service/services"
 )
 
 type PaymentRoute struct {
 	controller controllers.IControllerRegistry
 	client     clients.IClientRegistry
+	service    services.IServiceRegistry
 	group      *gin.RouterGroup
 }
 
@@ -18,27 +20,49 @@ type IPaymentRoute interface {
 	Run()
 }
 
-func NewPaymentRoute(group *gin.RouterGroup, controller controllers.IControllerRegistry, client clients.IClientRegistry) IPaymentRoute {
+func NewPaymentRoute(group *gin.RouterGroup, controller controllers.IControllerRegistry, client clients.IClientRegistry, service services.IServiceRegistry) IPaymentRoute {
 	return &PaymentRoute{
 		group:      group,
 		controller: controller,
 		client:     client,
+		service:    service,
 	}
 }
 
 func (p *PaymentRoute) Run() {
 	group := p.group.Group("/payment")
 	group.POST("/webhook", p.controller.GetPayment().Webhook)
+	group.POST("/webhook/:provider", p.controller.GetPayment().Webhook)
 	group.Use(middlewares.Authenticate())
 	group.GET("", middlewares.CheckRole([]string{
 		constants.Admin,
 		constants.Customer,
 	}, p.client), p.controller.GetPayment().GetAllWithPagination)
+	group.GET("/notifications", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, p.client), p.controller.GetPayment().GetAllNotificationWithPagination)
+	group.GET("/reviews", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, p.client), p.controller.GetPayment().GetAllReviewWithPagination)
+	group.POST("/reviews/:uuid/resolve", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, p.client), p.controller.GetPayment().ResolveReview)
 	group.GET("/:uuid", middlewares.CheckRole([]string{
 		constants.Admin,
 		constants.Customer,
 	}, p.client), p.controller.GetPayment().GetByUUID)
 	group.POST("", middlewares.CheckRole([]string{
 		constants.Customer,
-	}, p.client), p.controller.GetPayment().Create)
+	}, p.client), middlewares.Idempotency(p.service.GetIdempotency()), p.controller.GetPayment().Create)
+	group.POST("/:uuid/cancel", middlewares.CheckRole([]string{
+		constants.Admin,
+		constants.Customer,
+	}, p.client), p.controller.GetPayment().Cancel)
+	group.POST("/:uuid/renew", middlewares.CheckRole([]string{
+		constants.Admin,
+		constants.Customer,
+	}, p.client), p.controller.GetPayment().Renew)
+	group.POST("/:uuid/refund", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, p.client), p.controller.GetPayment().Refund)
 }
@@ -5,23 +5,26 @@ import (
 	"payment-service/clients"
 	controllers "payment-service/controllers/http"
 	routes "payment-service/routes/payment"
+	"payment-service/services"
 )
 
 type Registry struct {
 	controller controllers.IControllerRegistry
 	group      *gin.RouterGroup
 	client     clients.IClientRegistry
+	service    services.IServiceRegistry
 }
 
 type IRouteRegistry interface {
 	Serve()
 }
 
-func NewRouteRegistry(controller controllers.IControllerRegistry, group *gin.RouterGroup, client clients.IClientRegistry) IRouteRegistry {
+func NewRouteRegistry(controller controllers.IControllerRegistry, group *gin.RouterGroup, client clients.IClientRegistry, service services.IServiceRegistry) IRouteRegistry {
 	return &Registry{
 		controller: controller,
 		group:      group,
 		client:     client,
+		service:    service,
 	}
 }
 
@@ -30,5 +33,5 @@ func (r *Registry) Serve() {
 }
 
 func (r *Registry) paymentRoute() routes.IPaymentRoute {
-	return routes.NewPaymentRoute(r.group, r.controller, r.client)
+	return routes.NewPaymentRoute(r.group, r.controller, r.client, r.service)
 }
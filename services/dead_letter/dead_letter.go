@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"payment-service/config"
+	"payment-service/constants"
+	errDeadLetter "payment-service/constants/error/dead_letter"
+	"payment-service/controllers/kafka"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+	"payment-service/repositories"
+	"strconv"
+)
+
+type DeadLetterService struct {
+	repository repositories.IRepositoryRegistry
+	kafka      kafka.IKafkaRegistry
+}
+
+type IDeadLetterService interface {
+	GetAll(context.Context, *constants.DeadLetterStatus) ([]dto.DeadLetterResponse, error)
+	Create(context.Context, *gorm.DB, *dto.DeadLetterRequest) error
+	Redrive(context.Context, string) error
+}
+
+func NewDeadLetterService(repository repositories.IRepositoryRegistry, kafka kafka.IKafkaRegistry) IDeadLetterService {
+	return &DeadLetterService{
+		repository: repository,
+		kafka:      kafka,
+	}
+}
+
+func (d *DeadLetterService) toResponse(deadLetter *models.DeadLetter) dto.DeadLetterResponse {
+	var headers map[string]string
+	_ = json.Unmarshal([]byte(deadLetter.Headers), &headers)
+
+	return dto.DeadLetterResponse{
+		UUID:       deadLetter.UUID,
+		Source:     deadLetter.Source,
+		Topic:      deadLetter.Topic,
+		MessageKey: deadLetter.MessageKey,
+		Headers:    headers,
+		Payload:    deadLetter.Payload,
+		Error:      deadLetter.Error,
+		Attempts:   deadLetter.Attempts,
+		Status:     deadLetter.Status,
+		RedrivenAt: deadLetter.RedrivenAt,
+		CreatedAt:  deadLetter.CreatedAt,
+	}
+}
+
+func (d *DeadLetterService) GetAll(ctx context.Context, status *constants.DeadLetterStatus) ([]dto.DeadLetterResponse, error) {
+	deadLetters, err := d.repository.GetDeadLetter().FindAll(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dto.DeadLetterResponse, 0, len(deadLetters))
+	for _, deadLetter := range deadLetters {
+		results = append(results, d.toResponse(&deadLetter))
+	}
+
+	return results, nil
+}
+
+func (d *DeadLetterService) Create(ctx context.Context, tx *gorm.DB, request *dto.DeadLetterRequest) error {
+	if tx == nil {
+		tx = d.repository.GetTx()
+	}
+
+	deadLetter, err := d.repository.GetDeadLetter().Create(ctx, tx, request)
+	if err != nil {
+		return err
+	}
+
+	topic := config.Config.Kafka.DeadLetterTopic
+	if topic == "" {
+		return nil
+	}
+
+	headers := make(map[string]string, len(request.Headers)+3)
+	for key, value := range request.Headers {
+		headers[key] = value
+	}
+	headers[constants.KafkaHeaderOriginalTopic] = request.Topic
+	headers[constants.KafkaHeaderError] = request.Error
+	headers[constants.KafkaHeaderAttempts] = strconv.Itoa(request.Attempts)
+
+	var key string
+	if request.MessageKey != nil {
+		key = *request.MessageKey
+	}
+
+	err = d.kafka.GetKafkaProducer().ProducerMessage(topic, key, request.Payload, headers)
+	if err != nil {
+		logrus.Errorf("failed publish dead letter %s to %s: %v", deadLetter.UUID, topic, err)
+	}
+
+	return nil
+}
+
+func (d *DeadLetterService) Redrive(ctx context.Context, uuid string) error {
+	deadLetter, err := d.repository.GetDeadLetter().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	if deadLetter.Status == constants.DeadLetterRedriven {
+		return errDeadLetter.ErrDeadLetterAlreadyRedriven
+	}
+
+	var (
+		headers map[string]string
+		key     string
+	)
+	_ = json.Unmarshal([]byte(deadLetter.Headers), &headers)
+	if deadLetter.MessageKey != nil {
+		key = *deadLetter.MessageKey
+	}
+
+	err = d.kafka.GetKafkaProducer().ProducerMessage(deadLetter.Topic, key, []byte(deadLetter.Payload), headers)
+	if err != nil {
+		return err
+	}
+
+	return d.repository.GetDeadLetter().MarkRedriven(ctx, deadLetter.ID)
+}
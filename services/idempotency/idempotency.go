@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"errors"
+	errWrap "payment-service/common/error"
+	"payment-service/constants"
+	errIdempotency "payment-service/constants/error/idempotency"
+	"payment-service/domain/dto"
+	"payment-service/repositories"
+	"time"
+)
+
+const processingTimeout = time.Minute
+
+type IdempotencyService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IIdempotencyService interface {
+	Begin(context.Context, *dto.IdempotencyRequest) (*dto.IdempotencyResponse, error)
+	Complete(context.Context, *dto.IdempotencyRequest, *dto.IdempotencyResponse) error
+	Release(context.Context, *dto.IdempotencyRequest) error
+}
+
+func NewIdempotencyService(repository repositories.IRepositoryRegistry) IIdempotencyService {
+	return &IdempotencyService{repository: repository}
+}
+
+func (i *IdempotencyService) Begin(ctx context.Context, request *dto.IdempotencyRequest) (*dto.IdempotencyResponse, error) {
+	created, err := i.repository.GetIdempotency().Create(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if created {
+		return nil, nil
+	}
+
+	existing, err := i.repository.GetIdempotency().FindByKey(ctx, request)
+	if err != nil {
+		if errors.Is(err, errIdempotency.ErrIdempotencyKeyNotFound) {
+			return nil, errWrap.WrapError(errIdempotency.ErrIdempotencyRequestInProgress)
+		}
+		return nil, err
+	}
+
+	if existing.RequestHash != request.RequestHash {
+		return nil, errWrap.WrapError(errIdempotency.ErrIdempotencyKeyReused)
+	}
+
+	if existing.Status == constants.IdempotencyCompleted && existing.StatusCode != nil && existing.ResponseBody != nil {
+		return &dto.IdempotencyResponse{
+			StatusCode: *existing.StatusCode,
+			Body:       []byte(*existing.ResponseBody),
+		}, nil
+	}
+
+	takenOver, err := i.repository.GetIdempotency().TakeOver(ctx, request, time.Now().Add(-processingTimeout))
+	if err != nil {
+		return nil, err
+	}
+
+	if !takenOver {
+		return nil, errWrap.WrapError(errIdempotency.ErrIdempotencyRequestInProgress)
+	}
+
+	return nil, nil
+}
+
+func (i *IdempotencyService) Complete(ctx context.Context, request *dto.IdempotencyRequest, response *dto.IdempotencyResponse) error {
+	return i.repository.GetIdempotency().Complete(ctx, request, response)
+}
+
+func (i *IdempotencyService) Release(ctx context.Context, request *dto.IdempotencyRequest) error {
+	return i.repository.GetIdempotency().Delete(ctx, request)
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	userClient "payment-service/clients/user"
+	errWrap "payment-service/common/error"
+	"payment-service/constants"
+	errConstant "payment-service/constants/error"
+	errPayment "payment-service/constants/error/payment"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+)
+
+func (p *PaymentService) findOwnedPayment(ctx context.Context, uuid string) (*models.Payment, *userClient.UserData, error) {
+	user := p.currentUser(ctx)
+	if user == nil {
+		return nil, nil, errWrap.WrapError(errConstant.ErrUnauthorized)
+	}
+
+	payment, err := p.repository.GetPayment().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if user.Role != constants.Admin && (payment.UserID == nil || *payment.UserID != user.UUID) {
+		return nil, nil, errWrap.WrapError(errConstant.ErrForbidden)
+	}
+
+	return payment, user, nil
+}
+
+func (p *PaymentService) Cancel(ctx context.Context, uuid string) (*dto.PaymentResponse, error) {
+	payment, user, err := p.findOwnedPayment(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if *payment.Status != constants.Initial && *payment.Status != constants.Pending {
+		return nil, errWrap.WrapError(errPayment.ErrPaymentCannotBeCancelled)
+	}
+
+	err = p.voidAtGateway(ctx, payment)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.changeStatus(ctx, payment, constants.CancelString, fmt.Sprintf("cancelled by %s %s", user.Role, user.UUID))
+	if err != nil {
+		return nil, err
+	}
+
+	return p.GetByUUID(ctx, uuid)
+}
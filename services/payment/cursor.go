@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	errWrap "payment-service/common/error"
+	"payment-service/common/queryoption"
+	"payment-service/config"
+	errConstant "payment-service/constants/error"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+)
+
+func (p *PaymentService) findWithCursor(ctx context.Context, param *dto.PaymentRequestParam, preloads ...string) ([]models.Payment, *string, *string, error) {
+	var (
+		cursor    *queryoption.Cursor
+		direction = queryoption.Desc
+		err       error
+	)
+
+	if param.SortColumn != nil && *param.SortColumn != "" && *param.SortColumn != "created_at" {
+		return nil, nil, nil, errWrap.WrapError(errConstant.ErrInvalidSortColumn)
+	}
+
+	if param.Cursor != nil && *param.Cursor != "" {
+		cursor, err = queryoption.DecodeCursor(*param.Cursor, config.Config.CursorSecret)
+		if err != nil {
+			return nil, nil, nil, errWrap.WrapError(err)
+		}
+		direction = cursor.Direction
+	} else if param.SortOrder != nil {
+		direction, err = queryoption.ParseDirection(param.SortOrder)
+		if err != nil {
+			return nil, nil, nil, errWrap.WrapError(err)
+		}
+	}
+
+	payments, hasMore, err := p.repository.GetPayment().FindAllWithCursor(ctx, param, direction, cursor, preloads...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if len(payments) == 0 {
+		return payments, nil, nil, nil
+	}
+
+	var (
+		nextCursor *string
+		prevCursor *string
+		backward   = cursor != nil && cursor.Backward
+	)
+
+	if hasMore || backward {
+		nextCursor = p.encodeCursor(&payments[len(payments)-1], direction, false)
+	}
+
+	if cursor != nil && (!backward || hasMore) {
+		prevCursor = p.encodeCursor(&payments[0], direction, true)
+	}
+
+	return payments, nextCursor, prevCursor, nil
+}
+
+func (p *PaymentService) encodeCursor(payment *models.Payment, direction string, backward bool) *string {
+	cursor := queryoption.EncodeCursor(queryoption.Cursor{
+		CreatedAt: *payment.CreatedAt,
+		ID:        payment.ID,
+		Direction: direction,
+		Backward:  backward,
+	}, config.Config.CursorSecret)
+	return &cursor
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"payment-service/config"
+	"payment-service/constants"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+	"strconv"
+	"time"
+)
+
+func (p *PaymentService) eventVersions() []int {
+	if len(config.Config.Kafka.EventVersions) == 0 {
+		return []int{constants.KafkaSchemaVersionV1}
+	}
+
+	return config.Config.Kafka.EventVersions
+}
+
+func (p *PaymentService) eventTopic(version int) string {
+	if version == constants.KafkaSchemaVersionV2 && config.Config.Kafka.TopicV2 != "" {
+		return config.Config.Kafka.TopicV2
+	}
+
+	return config.Config.Kafka.Topic
+}
+
+func (p *PaymentService) buildKafkaMessageV1(eventName string, occurredAt time.Time, data *dto.KafkaData) ([]byte, error) {
+	return json.Marshal(dto.KafkaMessage{
+		Event: dto.KafkaEvent{
+			Name: eventName,
+		},
+		Metadata: dto.KafkaMetaData{
+			Sender:    constants.KafkaSender,
+			SendingAt: occurredAt.Format(time.RFC3339),
+		},
+		Body: dto.KafkaBody{
+			Type: "JSON",
+			Data: data,
+		},
+	})
+}
+
+func (p *PaymentService) buildKafkaMessageV2(eventID uuid.UUID, eventName string, occurredAt time.Time, data *dto.KafkaData) ([]byte, error) {
+	return json.Marshal(dto.KafkaMessageV2{
+		EventID:       eventID,
+		EventName:     eventName,
+		Version:       constants.KafkaSchemaVersionV2,
+		OccurredAt:    occurredAt,
+		AggregateID:   data.OrderID,
+		AggregateType: constants.KafkaAggregatePayment,
+		Producer:      constants.KafkaSender,
+		Data:          data,
+	})
+}
+
+func (p *PaymentService) produceToKafka(ctx context.Context, tx *gorm.DB, status constants.PaymentStatusString, payment *models.Payment, paidAt *time.Time) error {
+	var (
+		eventID    = uuid.New()
+		eventName  = p.mapTransactionStatusTOEvent(status)
+		occurredAt = time.Now()
+		data       = &dto.KafkaData{
+			OrderID:   payment.OrderID,
+			PaymentID: payment.UUID,
+			Status:    status.String(),
+			Amount:    payment.Amount,
+			PaidAt:    paidAt,
+			ExpiredAt: *payment.ExpiredAt,
+		}
+	)
+
+	for _, version := range p.eventVersions() {
+		var (
+			message []byte
+			err     error
+		)
+
+		switch version {
+		case constants.KafkaSchemaVersionV1:
+			message, err = p.buildKafkaMessageV1(eventName, occurredAt, data)
+		case constants.KafkaSchemaVersionV2:
+			message, err = p.buildKafkaMessageV2(eventID, eventName, occurredAt, data)
+		default:
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = p.repository.GetOutbox().Create(ctx, tx, &dto.OutboxRequest{
+			AggregateID: payment.OrderID.String(),
+			Topic:       p.eventTopic(version),
+			EventName:   eventName,
+			Payload:     message,
+			Headers: map[string]string{
+				constants.KafkaHeaderEventID:       eventID.String(),
+				constants.KafkaHeaderEventName:     eventName,
+				constants.KafkaHeaderSchemaVersion: strconv.Itoa(version),
+				constants.KafkaHeaderCorrelationID: p.correlationID(ctx),
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"os"
+	"payment-service/common/money"
+	"payment-service/constants"
+	"payment-service/domain/dto"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var paymentStatuses = []constants.PaymentStatusString{
+	constants.InitialString,
+	constants.PendingString,
+	constants.AuthorizeString,
+	constants.ChallengeString,
+	constants.CaptureString,
+	constants.SettlementString,
+	constants.ExpireString,
+	constants.CancelString,
+	constants.DenyString,
+	constants.FailureString,
+	constants.RefundString,
+	constants.PartialRefundString,
+}
+
+// schemaValidator covers the subset of JSON Schema used by schemas/payment.
+type schemaValidator struct {
+	root map[string]any
+}
+
+func loadSchema(t *testing.T, version string) *schemaValidator {
+	t.Helper()
+
+	content, err := os.ReadFile(fmt.Sprintf("../../schemas/payment/%s/payment-event.schema.json", version))
+	if err != nil {
+		t.Fatalf("failed read schema: %v", err)
+	}
+
+	var root map[string]any
+	if err = json.Unmarshal(content, &root); err != nil {
+		t.Fatalf("failed parse schema: %v", err)
+	}
+
+	return &schemaValidator{root: root}
+}
+
+func (s *schemaValidator) Validate(message []byte) []string {
+	var document any
+	if err := json.Unmarshal(message, &document); err != nil {
+		return []string{err.Error()}
+	}
+
+	return s.validate("$", s.root, document)
+}
+
+func (s *schemaValidator) resolve(schema map[string]any) map[string]any {
+	ref, ok := schema["$ref"].(string)
+	if !ok {
+		return schema
+	}
+
+	resolved := s.root
+	for _, segment := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		resolved = resolved[segment].(map[string]any)
+	}
+
+	return resolved
+}
+
+func (s *schemaValidator) validate(path string, schema map[string]any, value any) []string {
+	var violations []string
+	schema = s.resolve(schema)
+
+	if types, ok := schema["type"]; ok && !matchesType(types, value) {
+		return append(violations, fmt.Sprintf("%s: %v is not of type %v", path, value, types))
+	}
+
+	if expected, ok := schema["const"]; ok && !reflect.DeepEqual(expected, value) {
+		violations = append(violations, fmt.Sprintf("%s: %v is not %v", path, value, expected))
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, candidate := range enum {
+			found = found || reflect.DeepEqual(candidate, value)
+		}
+		if !found {
+			violations = append(violations, fmt.Sprintf("%s: %v is not one of %v", path, value, enum))
+		}
+	}
+
+	if text, ok := value.(string); ok {
+		violations = append(violations, validateString(path, schema, text)...)
+	}
+
+	object, ok := value.(map[string]any)
+	if !ok {
+		return violations
+	}
+
+	required, _ := schema["required"].([]any)
+	for _, name := range required {
+		if _, exists := object[name.(string)]; !exists {
+			violations = append(violations, fmt.Sprintf("%s: missing required property %s", path, name))
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	for name, property := range object {
+		propertySchema, known := properties[name]
+		if !known {
+			if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+				violations = append(violations, fmt.Sprintf("%s: unexpected property %s", path, name))
+			}
+			continue
+		}
+		violations = append(violations, s.validate(path+"."+name, propertySchema.(map[string]any), property)...)
+	}
+
+	return violations
+}
+
+func matchesType(types any, value any) bool {
+	candidates, ok := types.([]any)
+	if !ok {
+		candidates = []any{types}
+	}
+
+	for _, candidate := range candidates {
+		switch candidate {
+		case "object":
+			_, ok = value.(map[string]any)
+		case "string":
+			_, ok = value.(string)
+		case "integer":
+			number, isNumber := value.(float64)
+			ok = isNumber && number == float64(int64(number))
+		case "null":
+			ok = value == nil
+		default:
+			ok = false
+		}
+		if ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateString(path string, schema map[string]any, value string) []string {
+	var violations []string
+
+	if minLength, ok := schema["minLength"].(float64); ok && len(value) < int(minLength) {
+		violations = append(violations, fmt.Sprintf("%s: %q is shorter than %v", path, value, minLength))
+	}
+	if maxLength, ok := schema["maxLength"].(float64); ok && len(value) > int(maxLength) {
+		violations = append(violations, fmt.Sprintf("%s: %q is longer than %v", path, value, maxLength))
+	}
+
+	switch schema["format"] {
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339Nano, value); err != nil {
+			violations = append(violations, fmt.Sprintf("%s: %q is not a date-time", path, value))
+		}
+	case "uuid":
+		if _, err := uuid.Parse(value); err != nil {
+			violations = append(violations, fmt.Sprintf("%s: %q is not a uuid", path, value))
+		}
+	}
+
+	return violations
+}
+
+func kafkaData(status constants.PaymentStatusString, paid bool) *dto.KafkaData {
+	data := &dto.KafkaData{
+		OrderID:   uuid.New(),
+		PaymentID: uuid.New(),
+		Status:    status.String(),
+		Amount:    money.New(15000000, "IDR"),
+		ExpiredAt: time.Now().Add(time.Hour),
+	}
+	if paid {
+		paidAt := time.Now()
+		data.PaidAt = &paidAt
+	}
+
+	return data
+}
+
+func TestBuildKafkaMessageMatchesSchema(t *testing.T) {
+	service := &PaymentService{}
+	schemas := map[string]*schemaValidator{
+		"v1": loadSchema(t, "v1"),
+		"v2": loadSchema(t, "v2"),
+	}
+
+	for _, status := range paymentStatuses {
+		for _, paid := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/paid=%v", status, paid), func(t *testing.T) {
+				data := kafkaData(status, paid)
+				eventName := service.mapTransactionStatusTOEvent(status)
+
+				messageV1, err := service.buildKafkaMessageV1(eventName, time.Now(), data)
+				if err != nil {
+					t.Fatalf("buildKafkaMessageV1() error = %v", err)
+				}
+				for _, violation := range schemas["v1"].Validate(messageV1) {
+					t.Errorf("v1 %s", violation)
+				}
+
+				messageV2, err := service.buildKafkaMessageV2(uuid.New(), eventName, time.Now(), data)
+				if err != nil {
+					t.Fatalf("buildKafkaMessageV2() error = %v", err)
+				}
+				for _, violation := range schemas["v2"].Validate(messageV2) {
+					t.Errorf("v2 %s", violation)
+				}
+			})
+		}
+	}
+}
+
+func TestSchemaValidatorRejectsInvalidMessages(t *testing.T) {
+	validator := loadSchema(t, "v2")
+	service := &PaymentService{}
+
+	message, err := service.buildKafkaMessageV2(uuid.New(), "SETTLEMENT", time.Now(), kafkaData(constants.SettlementString, true))
+	if err != nil {
+		t.Fatalf("buildKafkaMessageV2() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(map[string]any)
+	}{
+		{name: "missing event id", mutate: func(m map[string]any) { delete(m, "eventID") }},
+		{name: "wrong version", mutate: func(m map[string]any) { m["version"] = 1 }},
+		{name: "unknown event name", mutate: func(m map[string]any) { m["eventName"] = "PAID" }},
+		{name: "invalid occurred at", mutate: func(m map[string]any) { m["occurredAt"] = "yesterday" }},
+		{name: "missing paid at", mutate: func(m map[string]any) { delete(m["data"].(map[string]any), "paidAt") }},
+		{name: "fractional amount", mutate: func(m map[string]any) {
+			m["data"].(map[string]any)["amount"].(map[string]any)["minorUnits"] = 10.5
+		}},
+		{name: "invalid payment id", mutate: func(m map[string]any) { m["data"].(map[string]any)["paymentID"] = "42" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var document map[string]any
+			_ = json.Unmarshal(message, &document)
+			tt.mutate(document)
+			mutated, _ := json.Marshal(document)
+
+			if violations := validator.Validate(mutated); len(violations) == 0 {
+				t.Errorf("expected schema violations")
+			}
+		})
+	}
+}
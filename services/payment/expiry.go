@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/sirupsen/logrus"
+	"payment-service/constants"
+	errPayment "payment-service/constants/error/payment"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+)
+
+func (p *PaymentService) ExpireOverdue(ctx context.Context, request *dto.ExpireOverdueRequest) (*dto.ExpireOverdueReport, error) {
+	report := &dto.ExpireOverdueReport{}
+
+	payments, err := p.repository.GetPayment().FindAllOverdue(ctx, constants.ExpirableStatuses, request.ExpiredBefore, request.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, payment := range payments {
+		if ctx.Err() != nil {
+			break
+		}
+
+		report.Scanned++
+		err = p.expireOverdue(ctx, &payment)
+		if err != nil {
+			if errors.Is(err, errPayment.ErrInvalidStatusTransition) {
+				report.Skipped++
+				continue
+			}
+			logrus.Errorf("failed expire payment %s: %v", payment.OrderID, err)
+			report.Failed++
+			continue
+		}
+		report.Expired++
+	}
+
+	return report, nil
+}
+
+func (p *PaymentService) expireOverdue(ctx context.Context, payment *models.Payment) error {
+	gateway, err := p.gateway.Get(payment.Provider)
+	if err != nil {
+		return err
+	}
+
+	err = gateway.ExpireTransaction(p.gatewayOrderID(ctx, payment))
+	if err != nil && !errors.Is(err, errPayment.ErrTransactionNotFound) {
+		return err
+	}
+
+	return p.changeStatus(ctx, payment, constants.ExpireString, "expired after passing expired_at")
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"fmt"
+	"payment-service/common/money"
+	"payment-service/common/util"
+	errPayment "payment-service/constants/error/payment"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+)
+
+func (p *PaymentService) validateItems(amount money.Money, items []dto.ItemDetail) error {
+	total := money.New(0, amount.Currency)
+	for _, item := range items {
+		if !item.Amount.SameCurrency(amount) {
+			return errPayment.ErrCurrencyMismatch
+		}
+		total = total.Add(item.Amount.Multiply(item.Quantity))
+	}
+
+	if total.Minor != amount.Minor {
+		return errPayment.ErrItemsAmountMismatch
+	}
+
+	return nil
+}
+
+func (p *PaymentService) toItemDetails(items []models.PaymentItem) []dto.ItemDetail {
+	itemDetails := make([]dto.ItemDetail, 0, len(items))
+	for _, item := range items {
+		itemDetails = append(itemDetails, dto.ItemDetail{
+			ID:       item.ItemID,
+			Amount:   item.Price,
+			Name:     item.Name,
+			Quantity: item.Quantity,
+		})
+	}
+
+	return itemDetails
+}
+
+func (p *PaymentService) toItemResponses(items []models.PaymentItem) []dto.PaymentItemResponse {
+	itemResponses := make([]dto.PaymentItemResponse, 0, len(items))
+	for _, item := range items {
+		itemResponses = append(itemResponses, dto.PaymentItemResponse{
+			ID:       item.ItemID,
+			Name:     item.Name,
+			Amount:   item.Price,
+			Quantity: item.Quantity,
+		})
+	}
+
+	return itemResponses
+}
+
+func (p *PaymentService) toInvoiceItems(payment *models.Payment, items []models.PaymentItem) []dto.InvoiceItem {
+	if len(items) == 0 {
+		return []dto.InvoiceItem{
+			{
+				Description: p.valueOrEmpty(payment.Description),
+				Price:       util.CurrencyFormat(&payment.Amount),
+			},
+		}
+	}
+
+	invoiceItems := make([]dto.InvoiceItem, 0, len(items))
+	for _, item := range items {
+		description := item.Name
+		if item.Quantity > 1 {
+			description = fmt.Sprintf("%s x%d", item.Name, item.Quantity)
+		}
+		subtotal := item.Price.Multiply(item.Quantity)
+		invoiceItems = append(invoiceItems, dto.InvoiceItem{
+			Description: description,
+			Price:       util.CurrencyFormat(&subtotal),
+		})
+	}
+
+	return invoiceItems
+}
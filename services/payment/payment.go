@@ -5,11 +5,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 	"math/rand"
 	"os"
 	"path/filepath"
-	clients "payment-service/clients/midtrans"
+	clients "payment-service/clients/gateway"
+	userClient "payment-service/clients/user"
+	errWrap "payment-service/common/error"
 	"payment-service/common/util"
 	"payment-service/config"
 	"payment-service/constants"
@@ -26,21 +30,31 @@ import (
 type PaymentService struct {
 	repository repositories.IRepositoryRegistry
 	kafka      kafka.IKafkaRegistry
-	midtrans   clients.IMidtransClient
+	gateway    clients.IGatewayRegistry
 }
 
 type IPaymentService interface {
 	GetAllWithPagination(context.Context, *dto.PaymentRequestParam) (*util.PaginationResult, error)
+	GetAllWithCursor(context.Context, *dto.PaymentRequestParam) (*util.CursorPaginationResult, error)
 	GetByUUID(context.Context, string) (*dto.PaymentResponse, error)
 	Create(context.Context, *dto.PaymentRequest) (*dto.PaymentResponse, error)
-	Webhook(context.Context, *dto.WebHook) error
+	Webhook(context.Context, *dto.WebhookRequest) error
+	GetAllNotificationWithPagination(context.Context, *dto.PaymentNotificationRequestParam) (*util.PaginationResult, error)
+	CancelByOrderID(context.Context, string, string) error
+	Reconcile(context.Context, *dto.ReconcileRequest) (*dto.ReconcileReport, error)
+	ExpireOverdue(context.Context, *dto.ExpireOverdueRequest) (*dto.ExpireOverdueReport, error)
+	Refund(context.Context, string, *dto.RefundRequest) (*dto.RefundResponse, error)
+	Cancel(context.Context, string) (*dto.PaymentResponse, error)
+	Renew(context.Context, string, *dto.RenewPaymentRequest) (*dto.PaymentResponse, error)
+	GetAllReviewWithPagination(context.Context, *dto.PaymentReviewRequestParam) (*util.PaginationResult, error)
+	ResolveReview(context.Context, string, *dto.ResolvePaymentReviewRequest) (*dto.PaymentReviewResponse, error)
 }
 
-func NewPaymentService(repository repositories.IRepositoryRegistry, kafka kafka.IKafkaRegistry, midtrans clients.IMidtransClient) *PaymentService {
+func NewPaymentService(repository repositories.IRepositoryRegistry, kafka kafka.IKafkaRegistry, gateway clients.IGatewayRegistry) *PaymentService {
 	return &PaymentService{
 		repository: repository,
 		kafka:      kafka,
-		midtrans:   midtrans,
+		gateway:    gateway,
 	}
 }
 
@@ -52,21 +66,7 @@ func (p *PaymentService) GetAllWithPagination(ctx context.Context, param *dto.Pa
 
 	paymentResults := make([]*dto.PaymentResponse, 0, len(payments))
 	for _, payment := range payments {
-		paymentResults = append(paymentResults, &dto.PaymentResponse{
-			UUID:          payment.UUID,
-			TransactionID: payment.TransactionID,
-			OrderID:       payment.OrderID,
-			Amount:        payment.Amount,
-			Status:        payment.Status.GetStatusString(),
-			PaymentLink:   payment.PaymentLink,
-			InvoiceLink:   payment.InvoiceLink,
-			VANumber:      payment.VANumber,
-			Bank:          payment.Bank,
-			Description:   payment.Description,
-			ExpiredAt:     payment.ExpiredAt,
-			CreatedAt:     payment.CreatedAt,
-			UpdatedAt:     payment.UpdatedAt,
-		})
+		paymentResults = append(paymentResults, p.toPaymentListResponse(&payment))
 	}
 
 	paginationParam := util.PaginationParam{
@@ -80,16 +80,72 @@ func (p *PaymentService) GetAllWithPagination(ctx context.Context, param *dto.Pa
 	return &response, nil
 }
 
+func (p *PaymentService) GetAllWithCursor(ctx context.Context, param *dto.PaymentRequestParam) (*util.CursorPaginationResult, error) {
+	payments, nextCursor, prevCursor, err := p.findWithCursor(ctx, param, "Items")
+	if err != nil {
+		return nil, err
+	}
+
+	paymentResults := make([]*dto.PaymentResponse, 0, len(payments))
+	for _, payment := range payments {
+		paymentResults = append(paymentResults, p.toPaymentListResponse(&payment))
+	}
+
+	return &util.CursorPaginationResult{
+		Limit:      param.Limit,
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+		Data:       paymentResults,
+	}, nil
+}
+
+func (p *PaymentService) toPaymentListResponse(payment *models.Payment) *dto.PaymentResponse {
+	return &dto.PaymentResponse{
+		UUID:          payment.UUID,
+		TransactionID: payment.TransactionID,
+		OrderID:       payment.OrderID,
+		Provider:      payment.Provider,
+		Amount:        payment.Amount,
+		Status:        payment.Status.GetStatusString(),
+		PaymentLink:   payment.PaymentLink,
+		InvoiceLink:   payment.InvoiceLink,
+		VANumber:      payment.VANumber,
+		Bank:          payment.Bank,
+		Description:   payment.Description,
+		ExpiredAt:     payment.ExpiredAt,
+		CreatedAt:     payment.CreatedAt,
+		UpdatedAt:     payment.UpdatedAt,
+		Items:         p.toItemResponses(payment.Items),
+	}
+}
+
 func (p *PaymentService) GetByUUID(ctx context.Context, uuid string) (*dto.PaymentResponse, error) {
 	payment, err := p.repository.GetPayment().FindByUUID(ctx, uuid)
 	if err != nil {
 		return nil, err
 	}
 
+	var activeAttempt *dto.PaymentAttemptResponse
+	attempt, err := p.repository.GetPaymentAttempt().FindActiveByPaymentID(ctx, payment.ID)
+	if err == nil {
+		activeAttempt = &dto.PaymentAttemptResponse{
+			UUID:           attempt.UUID,
+			GatewayOrderID: attempt.GatewayOrderID,
+			Sequence:       attempt.Sequence,
+			PaymentLink:    attempt.PaymentLink,
+			Status:         attempt.Status,
+			ExpiredAt:      attempt.ExpiredAt,
+			CreatedAt:      attempt.CreatedAt,
+		}
+	} else if !errors.Is(err, errPayment.ErrPaymentAttemptNotFound) {
+		return nil, err
+	}
+
 	return &dto.PaymentResponse{
 		UUID:          payment.UUID,
 		TransactionID: payment.TransactionID,
 		OrderID:       payment.OrderID,
+		Provider:      payment.Provider,
 		Amount:        payment.Amount,
 		Status:        payment.Status.GetStatusString(),
 		PaymentLink:   payment.PaymentLink,
@@ -100,6 +156,8 @@ func (p *PaymentService) GetByUUID(ctx context.Context, uuid string) (*dto.Payme
 		ExpiredAt:     payment.ExpiredAt,
 		CreatedAt:     payment.CreatedAt,
 		UpdatedAt:     payment.UpdatedAt,
+		ActiveAttempt: activeAttempt,
+		Items:         p.toItemResponses(payment.Items),
 	}, nil
 
 }
@@ -109,25 +167,46 @@ func (p *PaymentService) Create(ctx context.Context, request *dto.PaymentRequest
 		txErr, err error
 		payment    *models.Payment
 		response   *dto.PaymentResponse
-		midtrans   *clients.MidtransData
+		link       *dto.GatewayPaymentLink
 	)
 
+	err = p.validateItems(request.Amount, request.ItemDetails)
+	if err != nil {
+		return nil, errWrap.WrapError(err)
+	}
+
+	gateway, err := p.gatewayFor(request.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.repository.GetPayment().FindByOrderID(ctx, request.OrderID)
+	if err == nil {
+		return nil, errWrap.WrapError(errPayment.ErrPaymentAlreadyExists)
+	}
+	if !errors.Is(err, errPayment.ErrPaymentNotFound) {
+		return nil, err
+	}
+
 	err = p.repository.GetTx().Transaction(func(tx *gorm.DB) error {
 		if !request.ExpiredAt.After(time.Now()) {
 			return errPayment.ErrExpireAtInvalid
 		}
 
-		midtrans, txErr = p.midtrans.CreatePaymentLink(request)
+		link, txErr = gateway.CreatePaymentLink(request)
 		if txErr != nil {
 			return txErr
 		}
 
+		provider := gateway.Provider()
 		paymentRequest := &dto.PaymentRequest{
+			Provider:    &provider,
+			UserID:      p.currentUserID(ctx),
 			OrderID:     request.OrderID,
 			Amount:      request.Amount,
 			Description: request.Description,
 			ExpiredAt:   request.ExpiredAt,
-			PaymentLink: midtrans.RedirectURL,
+			PaymentLink: link.RedirectURL,
 		}
 
 		payment, txErr = p.repository.GetPayment().Create(ctx, tx, paymentRequest)
@@ -135,6 +214,22 @@ func (p *PaymentService) Create(ctx context.Context, request *dto.PaymentRequest
 			return txErr
 		}
 
+		payment.Items, txErr = p.repository.GetPaymentItem().CreateBatch(ctx, tx, payment.ID, request.ItemDetails)
+		if txErr != nil {
+			return txErr
+		}
+
+		_, txErr = p.repository.GetPaymentAttempt().Create(ctx, tx, &dto.PaymentAttemptRequest{
+			PaymentID:      payment.ID,
+			GatewayOrderID: util.GatewayOrderID(request.OrderID, 1),
+			Sequence:       1,
+			PaymentLink:    link.RedirectURL,
+			ExpiredAt:      &request.ExpiredAt,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
 		txErr = p.repository.GetPaymentHistory().Create(ctx, tx, &dto.PaymentHistoryRequest{
 			PaymentID: payment.ID,
 			Status:    payment.Status.GetStatusString(),
@@ -149,10 +244,12 @@ func (p *PaymentService) Create(ctx context.Context, request *dto.PaymentRequest
 	response = &dto.PaymentResponse{
 		UUID:        payment.UUID,
 		OrderID:     payment.OrderID,
+		Provider:    payment.Provider,
 		Amount:      payment.Amount,
 		Status:      payment.Status.GetStatusString(),
 		PaymentLink: payment.PaymentLink,
 		Description: payment.Description,
+		Items:       p.toItemResponses(payment.Items),
 	}
 
 	return response, nil
@@ -247,123 +344,191 @@ func (p *PaymentService) randomNumber() int {
 }
 
 func (p *PaymentService) mapTransactionStatusTOEvent(status constants.PaymentStatusString) string {
-	var paymentStatus string
-	switch status {
-	case constants.PendingString:
-		paymentStatus = strings.ToUpper(constants.PendingString.String())
-	case constants.SettlementString:
-		paymentStatus = strings.ToUpper(constants.SettlementString.String())
-	case constants.ExpireString:
-		paymentStatus = strings.ToUpper(constants.ExpireString.String())
-	}
-	return paymentStatus
+	return status.GetEventName()
 }
 
-func (p *PaymentService) produceToKafka(request *dto.WebHook, payment *models.Payment, paidAt *time.Time) error {
-	event := dto.KafkaEvent{
-		Name: p.mapTransactionStatusTOEvent(request.TransactionStatus),
+func (p *PaymentService) correlationID(ctx context.Context) string {
+	requestID, ok := ctx.Value(constants.RequestID).(string)
+	if !ok || requestID == "" {
+		return uuid.NewString()
 	}
 
-	metadata := dto.KafkaMetaData{
-		Sender:    "payment-service",
-		SendingAt: time.Now().Format(time.RFC3339),
+	return requestID
+}
+
+func (p *PaymentService) currentUser(ctx context.Context) *userClient.UserData {
+	user, ok := ctx.Value(constants.User).(*userClient.UserData)
+	if !ok {
+		return nil
 	}
 
-	body := dto.KafkaBody{
-		Type: "JSON",
-		Data: &dto.KafkaData{
-			OrderID:   payment.OrderID,
-			PaymentID: payment.UUID,
-			Status:    request.TransactionStatus.String(),
-			PaidAt:    paidAt,
-			ExpiredAt: *payment.ExpiredAt,
-		},
+	return user
+}
+
+func (p *PaymentService) currentUserID(ctx context.Context) *uuid.UUID {
+	user := p.currentUser(ctx)
+	if user == nil {
+		return nil
 	}
 
-	kafkaMessage := dto.KafkaMessage{
-		Event:    event,
-		Metadata: metadata,
-		Body:     body,
+	return &user.UUID
+}
+
+func (p *PaymentService) gatewayFor(provider *constants.PaymentProvider) (clients.IPaymentGateway, error) {
+	if provider != nil {
+		return p.gateway.Get(*provider)
 	}
 
-	topic := config.Config.Kafka.Topic
-	kafkaMessageJSON, _ := json.Marshal(kafkaMessage)
-	err := p.kafka.GetKafkaProducer().ProducerMessage(topic, kafkaMessageJSON)
+	return p.gateway.Get(constants.PaymentProvider(config.Config.DefaultProvider))
+}
+
+func (p *PaymentService) valueOrEmpty(values ...*string) string {
+	for _, value := range values {
+		if value != nil {
+			return *value
+		}
+	}
+
+	return ""
+}
+
+func (p *PaymentService) Webhook(ctx context.Context, request *dto.WebhookRequest) error {
+	gateway, err := p.gateway.Get(request.Provider)
 	if err != nil {
-		return err
+		return errWrap.WrapError(err)
 	}
 
-	return nil
+	transaction, err := gateway.ParseNotification(request.Header, request.Body)
+	if err != nil {
+		return errWrap.WrapError(err)
+	}
+
+	return p.applyTransaction(ctx, transaction)
 }
 
-func (p *PaymentService) Webhook(ctx context.Context, request *dto.WebHook) error {
+func (p *PaymentService) applyTransaction(ctx context.Context, transaction *dto.GatewayTransaction) error {
 	var (
 		txErr, err         error
+		payment            *models.Payment
 		paymentAfterUpdate *models.Payment
 		paidAt             *time.Time
 		invoiceLink        string
 		pdf                []byte
+		statusString       = transaction.Status
+		orderID            = transaction.OrderID.String()
 	)
 
 	err = p.repository.GetTx().Transaction(func(tx *gorm.DB) error {
-		_, err = p.repository.GetPayment().FindByOrderID(ctx, request.OrderID.String())
-		if err != nil {
+		_, txErr = p.repository.GetPaymentNotification().Create(ctx, tx, &dto.PaymentNotificationRequest{
+			Provider:          transaction.Provider,
+			OrderID:           transaction.OrderID,
+			TransactionID:     transaction.TransactionID,
+			TransactionStatus: transaction.Status,
+			StatusCode:        transaction.StatusCode,
+			RefundKey:         p.valueOrEmpty(transaction.RefundKey),
+			Payload:           transaction.RawPayload,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		payment, txErr = p.repository.GetPayment().FindByOrderIDForUpdate(ctx, tx, orderID)
+		if txErr != nil {
 			return txErr
 		}
 
-		if request.TransactionStatus == constants.SettlementString {
+		if payment.Provider != transaction.Provider {
+			logrus.Warnf("rejected %s notification for %s payment %s", transaction.Provider, payment.Provider, orderID)
+			return errWrap.WrapError(errPayment.ErrProviderMismatch)
+		}
+
+		status := statusString.GetStatusInt()
+		if !status.IsPaid() && p.isSupersededAttempt(ctx, transaction.GatewayOrderID) {
+			description := fmt.Sprintf("ignored %s from superseded attempt %s", statusString, transaction.GatewayOrderID)
+			return p.repository.GetPaymentHistory().Create(ctx, tx, &dto.PaymentHistoryRequest{
+				PaymentID:   payment.ID,
+				Status:      statusString,
+				Description: &description,
+			})
+		}
+
+		if status.IsPaid() {
 			now := time.Now()
 			paidAt = &now
 		}
 
-		status := request.TransactionStatus.GetStatusInt()
-		vaNumber := request.VANumbers[0].VaNumber
-		bank := request.VANumbers[0].Bank
-		_, txErr = p.repository.GetPayment().Update(ctx, tx, request.OrderID.String(), &dto.UpdatePaymentRequest{
-			TransactionID: &request.TransactionID,
+		_, txErr = p.repository.GetPayment().Update(ctx, tx, orderID, &dto.UpdatePaymentRequest{
+			TransactionID: &transaction.TransactionID,
 			Status:        &status,
 			PaidAt:        paidAt,
-			VANumber:      &vaNumber,
-			Bank:          &bank,
-			Acquirer:      request.Acquirer,
+			VANumber:      transaction.VANumber,
+			Bank:          transaction.Bank,
+			Acquirer:      transaction.Acquirer,
 		})
 
 		if txErr != nil {
+			if errors.Is(txErr, errPayment.ErrInvalidStatusTransition) {
+				description := fmt.Sprintf("rejected transition from %s to %s",
+					payment.Status.GetStatusString(), statusString)
+				return p.repository.GetPaymentHistory().Create(ctx, tx, &dto.PaymentHistoryRequest{
+					PaymentID:   payment.ID,
+					Status:      statusString,
+					Description: &description,
+				})
+			}
 			return txErr
 		}
 
-		paymentAfterUpdate, err = p.repository.GetPayment().FindByOrderID(ctx, request.OrderID.String())
-		if err != nil {
+		paymentAfterUpdate, txErr = p.repository.GetPayment().FindByOrderID(ctx, orderID)
+		if txErr != nil {
 			return txErr
 		}
 
 		txErr = p.repository.GetPaymentHistory().Create(ctx, tx, &dto.PaymentHistoryRequest{
 			PaymentID: paymentAfterUpdate.ID,
-			Status:    paymentAfterUpdate.Status.GetStatusString(),
+			Status:    statusString,
 		})
+		if txErr != nil {
+			return txErr
+		}
 
-		if request.TransactionStatus == constants.SettlementString {
+		reason := p.verifyAmount(payment, transaction)
+		if reason != "" {
+			txErr = p.flagForReview(ctx, tx, payment, transaction, reason)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		if statusString == constants.RefundString || statusString == constants.PartialRefundString {
+			txErr = p.repository.GetRefund().MarkSucceeded(ctx, tx, paymentAfterUpdate.ID, transaction.RefundKey)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		if statusString == constants.SettlementString {
 			paidDay := paidAt.Format("02")
 			paidMonth := p.convertToIndonesianMonth(paidAt.Format("January"))
 			paidYear := paidAt.Format("2006")
 			invoiceNumber := fmt.Sprintf("INV/%s/ORD/%d", time.Now().Format(time.DateOnly), p.randomNumber())
-			total := util.RupiahFormat(&paymentAfterUpdate.Amount)
+			items, itemErr := p.repository.GetPaymentItem().FindByPaymentID(ctx, paymentAfterUpdate.ID)
+			if itemErr != nil {
+				return itemErr
+			}
+
+			total := util.CurrencyFormat(&paymentAfterUpdate.Amount)
 			invoiceRequest := &dto.InvoiceRequest{
 				InvoiceNumber: invoiceNumber,
 				Data: dto.InvoiceData{
 					PaymentDetail: dto.InvoicePaymentDetail{
-						PaymentMethod: request.PaymentType,
-						BankName:      strings.ToUpper(*paymentAfterUpdate.Bank),
-						VANumber:      *paymentAfterUpdate.VANumber,
+						PaymentMethod: transaction.PaymentType,
+						BankName:      strings.ToUpper(p.valueOrEmpty(transaction.Bank, paymentAfterUpdate.Bank)),
+						VANumber:      p.valueOrEmpty(transaction.VANumber, paymentAfterUpdate.VANumber),
 						Date:          fmt.Sprintf("%s %s %s", paidDay, paidMonth, paidYear),
 						IsPaid:        true,
 					},
-					Items: []dto.InvoiceItem{
-						{
-							Description: *paymentAfterUpdate.Description,
-							Price:       total,
-						},
-					},
+					Items: p.toInvoiceItems(paymentAfterUpdate, items),
 					Total: total,
 				},
 			}
@@ -377,24 +542,135 @@ func (p *PaymentService) Webhook(ctx context.Context, request *dto.WebHook) erro
 				return txErr
 			}
 
-			_, txErr = p.repository.GetPayment().Update(ctx, tx, request.OrderID.String(), &dto.UpdatePaymentRequest{
+			_, txErr = p.repository.GetPayment().Update(ctx, tx, orderID, &dto.UpdatePaymentRequest{
 				InvoiceLink: &invoiceLink,
 			})
 			if txErr != nil {
 				return txErr
 			}
 		}
-		return nil
+
+		if status.IsPaid() {
+			underReview, reviewErr := p.repository.GetPaymentReview().HasOpenByPaymentID(ctx, tx, paymentAfterUpdate.ID)
+			if reviewErr != nil {
+				return reviewErr
+			}
+
+			if underReview {
+				logrus.Warnf("withholding %s event for payment %s pending review", statusString, paymentAfterUpdate.OrderID)
+				return nil
+			}
+		}
+
+		return p.produceToKafka(ctx, tx, statusString, paymentAfterUpdate, paidAt)
 	})
 
 	if err != nil {
+		if errors.Is(err, errPayment.ErrNotificationAlreadyProcessed) {
+			logrus.Infof("skip duplicate notification %s (%s)", transaction.TransactionID, transaction.Status)
+			return nil
+		}
 		return err
 	}
 
-	err = p.produceToKafka(request, paymentAfterUpdate, paidAt)
+	return nil
+}
+
+func (p *PaymentService) GetAllNotificationWithPagination(ctx context.Context, param *dto.PaymentNotificationRequestParam) (*util.PaginationResult, error) {
+	notifications, total, err := p.repository.GetPaymentNotification().FindAllWithPagination(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	notificationResults := make([]*dto.PaymentNotificationResponse, 0, len(notifications))
+	for _, notification := range notifications {
+		notificationResults = append(notificationResults, &dto.PaymentNotificationResponse{
+			Provider:          notification.Provider,
+			OrderID:           notification.OrderID,
+			TransactionID:     notification.TransactionID,
+			TransactionStatus: notification.TransactionStatus,
+			StatusCode:        notification.StatusCode,
+			Payload:           json.RawMessage(notification.Payload),
+			CreatedAt:         notification.CreatedAt,
+		})
+	}
+
+	paginationParam := util.PaginationParam{
+		Page:  param.Page,
+		Limit: param.Limit,
+		Count: total,
+		Data:  notificationResults,
+	}
+
+	response := util.GeneratePagination(paginationParam)
+	return &response, nil
+}
+
+func (p *PaymentService) voidAtGateway(ctx context.Context, payment *models.Payment) error {
+	gateway, err := p.gateway.Get(payment.Provider)
 	if err != nil {
 		return err
 	}
 
+	return p.voidAttemptAtGateway(gateway, *payment.Status, p.gatewayOrderID(ctx, payment))
+}
+
+func (p *PaymentService) voidAttemptAtGateway(gateway clients.IPaymentGateway, status constants.PaymentStatus, gatewayOrderID string) error {
+	var err error
+	if status == constants.Pending {
+		err = gateway.ExpireTransaction(gatewayOrderID)
+	} else {
+		err = gateway.CancelTransaction(gatewayOrderID)
+	}
+
+	if err != nil && !errors.Is(err, errPayment.ErrTransactionNotFound) {
+		return err
+	}
+
 	return nil
 }
+
+func (p *PaymentService) changeStatus(ctx context.Context, payment *models.Payment, status constants.PaymentStatusString, description string) error {
+	return p.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		statusInt := status.GetStatusInt()
+		_, err := p.repository.GetPayment().Update(ctx, tx, payment.OrderID.String(), &dto.UpdatePaymentRequest{
+			Status: &statusInt,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = p.repository.GetPaymentHistory().Create(ctx, tx, &dto.PaymentHistoryRequest{
+			PaymentID:   payment.ID,
+			Status:      status,
+			Description: &description,
+		})
+		if err != nil {
+			return err
+		}
+
+		return p.produceToKafka(ctx, tx, status, payment, nil)
+	})
+}
+
+func (p *PaymentService) CancelByOrderID(ctx context.Context, orderID, reason string) error {
+	payment, err := p.repository.GetPayment().FindByOrderID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	if *payment.Status == constants.Cancel {
+		return nil
+	}
+
+	if payment.Status.IsPaid() || !payment.Status.CanTransitionTo(constants.Cancel) {
+		return errWrap.WrapError(errPayment.ErrPaymentCannotBeCancelled)
+	}
+
+	err = p.voidAtGateway(ctx, payment)
+	if err != nil {
+		return err
+	}
+
+	return p.changeStatus(ctx, payment, constants.CancelString, reason)
+}
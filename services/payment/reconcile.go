@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"payment-service/common/queryoption"
+	"payment-service/constants"
+	errPayment "payment-service/constants/error/payment"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+	"time"
+)
+
+func (p *PaymentService) Reconcile(ctx context.Context, request *dto.ReconcileRequest) (*dto.ReconcileReport, error) {
+	report := &dto.ReconcileReport{
+		StartedAt:     time.Now(),
+		Discrepancies: []dto.ReconcileDiscrepancy{},
+	}
+
+	statuses := make([]constants.PaymentStatusString, 0, len(constants.ReconcilableStatuses))
+	for _, status := range constants.ReconcilableStatuses {
+		statuses = append(statuses, status.GetStatusString())
+	}
+
+	sortOrder := queryoption.Asc
+	var cursor *string
+	for {
+		payments, nextCursor, _, err := p.findWithCursor(ctx, &dto.PaymentRequestParam{
+			Limit:     request.Limit,
+			SortOrder: &sortOrder,
+			Status:    statuses,
+			UpdatedTo: &request.UpdatedBefore,
+			Cursor:    cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, payment := range payments {
+			if ctx.Err() != nil {
+				break
+			}
+
+			report.Scanned++
+			discrepancy := p.reconcilePayment(ctx, &payment)
+			if discrepancy == nil {
+				report.InSync++
+				continue
+			}
+
+			switch discrepancy.Action {
+			case constants.ReconcileApplied:
+				report.Applied++
+			case constants.ReconcileRejected:
+				report.Rejected++
+			case constants.ReconcileNotFound:
+				report.NotFound++
+			case constants.ReconcileFailed:
+				report.Failed++
+			}
+			report.Discrepancies = append(report.Discrepancies, *discrepancy)
+		}
+
+		if ctx.Err() != nil || nextCursor == nil {
+			break
+		}
+		cursor = nextCursor
+	}
+
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+func (p *PaymentService) reconcilePayment(ctx context.Context, payment *models.Payment) *dto.ReconcileDiscrepancy {
+	discrepancy := &dto.ReconcileDiscrepancy{
+		PaymentUUID: payment.UUID,
+		OrderID:     payment.OrderID,
+		Provider:    payment.Provider,
+		LocalStatus: payment.Status.GetStatusString(),
+	}
+
+	failed := func(err error) *dto.ReconcileDiscrepancy {
+		message := err.Error()
+		discrepancy.Action = constants.ReconcileFailed
+		discrepancy.Error = &message
+		return discrepancy
+	}
+
+	gateway, err := p.gateway.Get(payment.Provider)
+	if err != nil {
+		return failed(err)
+	}
+
+	transaction, err := gateway.GetTransaction(p.gatewayOrderID(ctx, payment))
+	if err != nil {
+		if errors.Is(err, errPayment.ErrTransactionNotFound) {
+			if *payment.Status == constants.Initial {
+				return nil
+			}
+			discrepancy.Action = constants.ReconcileNotFound
+			return discrepancy
+		}
+		return failed(err)
+	}
+
+	discrepancy.GatewayStatus = transaction.Status
+	status := transaction.Status.GetStatusInt()
+	if status == *payment.Status {
+		return nil
+	}
+
+	if !payment.Status.CanTransitionTo(status) {
+		discrepancy.Action = constants.ReconcileRejected
+		return discrepancy
+	}
+
+	err = p.applyTransaction(ctx, transaction)
+	if err != nil {
+		return failed(err)
+	}
+
+	discrepancy.Action = constants.ReconcileApplied
+	return discrepancy
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	errWrap "payment-service/common/error"
+	"payment-service/constants"
+	errPayment "payment-service/constants/error/payment"
+	errRefund "payment-service/constants/error/refund"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+)
+
+func (p *PaymentService) Refund(ctx context.Context, uuid string, request *dto.RefundRequest) (*dto.RefundResponse, error) {
+	var (
+		payment *models.Payment
+		refund  *models.Refund
+	)
+
+	err := p.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		var txErr error
+		payment, txErr = p.repository.GetPayment().FindByUUIDForUpdate(ctx, tx, uuid)
+		if txErr != nil {
+			return txErr
+		}
+
+		if !payment.Status.CanTransitionTo(constants.Refund) {
+			return errWrap.WrapError(errRefund.ErrPaymentNotRefundable)
+		}
+
+		refunded, txErr := p.repository.GetRefund().SumAmountByPaymentID(ctx, tx, payment.ID)
+		if txErr != nil {
+			return txErr
+		}
+
+		if !request.Amount.SameCurrency(payment.Amount) {
+			return errWrap.WrapError(errPayment.ErrCurrencyMismatch)
+		}
+
+		if refunded+request.Amount.Minor > payment.Amount.Minor {
+			return errWrap.WrapError(errRefund.ErrRefundAmountExceeded)
+		}
+
+		refund, txErr = p.repository.GetRefund().Create(ctx, tx, &dto.CreateRefundRequest{
+			PaymentID: payment.ID,
+			Amount:    request.Amount,
+			Reason:    request.Reason,
+		})
+		return txErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	gateway, err := p.gateway.Get(payment.Provider)
+	if err == nil {
+		_, err = gateway.RefundTransaction(p.gatewayOrderID(ctx, payment), &dto.GatewayRefundRequest{
+			RefundKey: refund.RefundKey,
+			Amount:    refund.Amount,
+			Reason:    refund.Reason,
+		})
+	}
+	if err != nil {
+		if !p.isRefundRejected(err) {
+			logrus.Warnf("refund %s outcome is unknown, keeping it pending: %v", refund.RefundKey, err)
+			return p.toRefundResponse(payment, refund), nil
+		}
+
+		markErr := p.repository.GetRefund().MarkFailed(ctx, refund.ID, err)
+		if markErr != nil {
+			return nil, markErr
+		}
+		if errors.Is(err, errRefund.ErrRefundRejected) {
+			err = errRefund.ErrRefundRejected
+		}
+		return nil, errWrap.WrapError(err)
+	}
+
+	return p.toRefundResponse(payment, refund), nil
+}
+
+// isRefundRejected reports whether the gateway definitively did not refund.
+// Timeouts and server errors leave the refund pending, still counted against
+// the refundable amount, until the webhook or reconcile settles it.
+func (p *PaymentService) isRefundRejected(err error) bool {
+	return errors.Is(err, errRefund.ErrRefundRejected) ||
+		errors.Is(err, errPayment.ErrTransactionNotFound) ||
+		errors.Is(err, errPayment.ErrUnsupportedProvider) ||
+		errors.Is(err, errPayment.ErrUnsupportedCurrency) ||
+		errors.Is(err, errPayment.ErrFractionalAmount)
+}
+
+func (p *PaymentService) toRefundResponse(payment *models.Payment, refund *models.Refund) *dto.RefundResponse {
+	return &dto.RefundResponse{
+		UUID:        refund.UUID,
+		PaymentUUID: payment.UUID,
+		OrderID:     payment.OrderID,
+		RefundKey:   refund.RefundKey,
+		Amount:      refund.Amount,
+		Reason:      refund.Reason,
+		Status:      refund.Status,
+		CreatedAt:   refund.CreatedAt,
+		UpdatedAt:   refund.UpdatedAt,
+	}
+}
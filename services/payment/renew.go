@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	errWrap "payment-service/common/error"
+	"payment-service/common/util"
+	"payment-service/constants"
+	errPayment "payment-service/constants/error/payment"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+	"time"
+)
+
+func (p *PaymentService) gatewayOrderID(ctx context.Context, payment *models.Payment) string {
+	attempt, err := p.repository.GetPaymentAttempt().FindActiveByPaymentID(ctx, payment.ID)
+	if err != nil {
+		return payment.OrderID.String()
+	}
+
+	return attempt.GatewayOrderID
+}
+
+func (p *PaymentService) isSupersededAttempt(ctx context.Context, gatewayOrderID string) bool {
+	if gatewayOrderID == "" {
+		return false
+	}
+
+	attempt, err := p.repository.GetPaymentAttempt().FindByGatewayOrderID(ctx, gatewayOrderID)
+	if err != nil {
+		return false
+	}
+
+	return attempt.Status == constants.PaymentAttemptSuperseded
+}
+
+func (p *PaymentService) Renew(ctx context.Context, uuid string, request *dto.RenewPaymentRequest) (*dto.PaymentResponse, error) {
+	if !request.ExpiredAt.After(time.Now()) {
+		return nil, errWrap.WrapError(errPayment.ErrExpireAtInvalid)
+	}
+
+	payment, _, err := p.findOwnedPayment(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(request.ItemDetails) > 0 {
+		err = p.validateItems(payment.Amount, request.ItemDetails)
+		if err != nil {
+			return nil, errWrap.WrapError(err)
+		}
+	}
+
+	gateway, err := p.gateway.Get(payment.Provider)
+	if err != nil {
+		return nil, errWrap.WrapError(err)
+	}
+
+	err = p.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		locked, txErr := p.repository.GetPayment().FindByUUIDForUpdate(ctx, tx, uuid)
+		if txErr != nil {
+			return txErr
+		}
+		payment = locked
+
+		if !payment.Status.CanRenew() {
+			return errWrap.WrapError(errPayment.ErrPaymentCannotBeRenewed)
+		}
+
+		attempts, txErr := p.repository.GetPaymentAttempt().CountByPaymentID(ctx, tx, payment.ID)
+		if txErr != nil {
+			return txErr
+		}
+
+		itemDetails := request.ItemDetails
+		if len(itemDetails) == 0 {
+			items, itemErr := p.repository.GetPaymentItem().FindByPaymentID(ctx, payment.ID)
+			if itemErr != nil {
+				return itemErr
+			}
+			itemDetails = p.toItemDetails(items)
+		}
+
+		previousOrderID := p.gatewayOrderID(ctx, payment)
+		txErr = p.repository.GetPaymentAttempt().SupersedeByPaymentID(ctx, tx, payment.ID)
+		if txErr != nil {
+			return txErr
+		}
+
+		if *payment.Status != constants.Expire {
+			txErr = p.voidAttemptAtGateway(gateway, *payment.Status, previousOrderID)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		sequence := int(max(attempts, 1)) + 1
+		gatewayOrderID := util.GatewayOrderID(payment.OrderID.String(), sequence)
+		link, txErr := gateway.CreatePaymentLink(&dto.PaymentRequest{
+			OrderID:        gatewayOrderID,
+			Amount:         payment.Amount,
+			Description:    payment.Description,
+			ExpiredAt:      request.ExpiredAt,
+			CustomerDetail: request.CustomerDetail,
+			ItemDetails:    itemDetails,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		_, txErr = p.repository.GetPaymentAttempt().Create(ctx, tx, &dto.PaymentAttemptRequest{
+			PaymentID:      payment.ID,
+			GatewayOrderID: gatewayOrderID,
+			Sequence:       sequence,
+			PaymentLink:    link.RedirectURL,
+			ExpiredAt:      &request.ExpiredAt,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = p.repository.GetPayment().ResetForRenewal(ctx, tx, payment.ID, link.RedirectURL, request.ExpiredAt)
+		if txErr != nil {
+			return txErr
+		}
+
+		if len(request.ItemDetails) > 0 {
+			_, txErr = p.repository.GetPaymentItem().ReplaceByPaymentID(ctx, tx, payment.ID, request.ItemDetails)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		description := fmt.Sprintf("renewed payment link as attempt %d (%s)", sequence, gatewayOrderID)
+		txErr = p.repository.GetPaymentHistory().Create(ctx, tx, &dto.PaymentHistoryRequest{
+			PaymentID:   payment.ID,
+			Status:      constants.InitialString,
+			Description: &description,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		if *payment.Status == constants.Initial {
+			return nil
+		}
+
+		payment.ExpiredAt = &request.ExpiredAt
+		return p.produceToKafka(ctx, tx, constants.InitialString, payment, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.GetByUUID(ctx, uuid)
+}
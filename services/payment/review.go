@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	errWrap "payment-service/common/error"
+	"payment-service/common/money"
+	"payment-service/common/util"
+	"payment-service/constants"
+	errPayment "payment-service/constants/error/payment"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+	"strings"
+)
+
+var amountMismatchTotal = expvar.NewInt("payment_amount_mismatch_total")
+
+func (p *PaymentService) verifyAmount(payment *models.Payment, transaction *dto.GatewayTransaction) string {
+	var reasons []string
+
+	if transaction.GrossAmount != "" {
+		notifiedAmount, err := money.Parse(transaction.GrossAmount, payment.Amount.Currency)
+		if err != nil {
+			reasons = append(reasons, fmt.Sprintf("unparseable gross amount %q", transaction.GrossAmount))
+		} else if notifiedAmount.Minor != payment.Amount.Minor {
+			reasons = append(reasons, fmt.Sprintf("gross amount %s does not match %s", transaction.GrossAmount, payment.Amount.Decimal()))
+		}
+	}
+
+	if transaction.Currency != "" && !strings.EqualFold(transaction.Currency, payment.Amount.Currency) {
+		reasons = append(reasons, fmt.Sprintf("currency %s does not match %s", transaction.Currency, payment.Amount.Currency))
+	}
+
+	return strings.Join(reasons, "; ")
+}
+
+func (p *PaymentService) flagForReview(
+	ctx context.Context,
+	tx *gorm.DB,
+	payment *models.Payment,
+	transaction *dto.GatewayTransaction,
+	reason string,
+) error {
+	_, err := p.repository.GetPaymentReview().Create(ctx, tx, &dto.PaymentReviewRequest{
+		PaymentID:         payment.ID,
+		OrderID:           payment.OrderID,
+		TransactionID:     transaction.TransactionID,
+		TransactionStatus: transaction.Status,
+		ExpectedAmount:    payment.Amount,
+		NotifiedAmount:    transaction.GrossAmount,
+		NotifiedCurrency:  strings.ToUpper(transaction.Currency),
+		Reason:            reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	amountMismatchTotal.Add(1)
+	logrus.Warnf("payment %s flagged for review on %s notification: %s", payment.OrderID, transaction.Status, reason)
+	return nil
+}
+
+func (p *PaymentService) GetAllReviewWithPagination(ctx context.Context, param *dto.PaymentReviewRequestParam) (*util.PaginationResult, error) {
+	reviews, total, err := p.repository.GetPaymentReview().FindAllWithPagination(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewResults := make([]*dto.PaymentReviewResponse, 0, len(reviews))
+	for _, review := range reviews {
+		reviewResults = append(reviewResults, p.toReviewResponse(&review))
+	}
+
+	paginationParam := util.PaginationParam{
+		Page:  param.Page,
+		Limit: param.Limit,
+		Count: total,
+		Data:  reviewResults,
+	}
+
+	response := util.GeneratePagination(paginationParam)
+	return &response, nil
+}
+
+func (p *PaymentService) ResolveReview(ctx context.Context, uuid string, request *dto.ResolvePaymentReviewRequest) (*dto.PaymentReviewResponse, error) {
+	review, err := p.repository.GetPaymentReview().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if review.Status != constants.PaymentReviewOpen {
+		return nil, errWrap.WrapError(errPayment.ErrPaymentReviewAlreadyResolved)
+	}
+
+	err = p.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		txErr := p.repository.GetPaymentReview().Resolve(ctx, tx, review.ID, request)
+		if txErr != nil {
+			return txErr
+		}
+
+		if request.Status != constants.PaymentReviewApproved {
+			return nil
+		}
+
+		hasOpen, txErr := p.repository.GetPaymentReview().HasOpenByPaymentID(ctx, tx, review.PaymentID)
+		if txErr != nil || hasOpen {
+			return txErr
+		}
+
+		payment, txErr := p.repository.GetPayment().FindByOrderIDForUpdate(ctx, tx, review.OrderID.String())
+		if txErr != nil {
+			return txErr
+		}
+
+		if payment.Status == nil || !payment.Status.IsPaid() {
+			return nil
+		}
+
+		statusString := payment.Status.GetStatusString()
+		logrus.Infof("releasing withheld %s event for payment %s", statusString, payment.OrderID)
+		return p.produceToKafka(ctx, tx, statusString, payment, payment.PaidAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	review, err = p.repository.GetPaymentReview().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.toReviewResponse(review), nil
+}
+
+func (p *PaymentService) toReviewResponse(review *models.PaymentReview) *dto.PaymentReviewResponse {
+	return &dto.PaymentReviewResponse{
+		UUID:              review.UUID,
+		OrderID:           review.OrderID,
+		TransactionID:     review.TransactionID,
+		TransactionStatus: review.TransactionStatus,
+		ExpectedAmount:    review.ExpectedAmount,
+		NotifiedAmount:    review.NotifiedAmount,
+		NotifiedCurrency:  review.NotifiedCurrency,
+		Reason:            review.Reason,
+		Status:            review.Status,
+		Note:              review.Note,
+		ResolvedAt:        review.ResolvedAt,
+		CreatedAt:         review.CreatedAt,
+	}
+}
@@ -1,30 +1,42 @@
 package services
 
 import (
-	clients "payment-service/clients/midtrans"
+	clients "payment-service/clients/gateway"
 	"payment-service/controllers/kafka"
 	"payment-service/repositories"
+	deadLetterService "payment-service/services/dead_letter"
+	idempotencyService "payment-service/services/idempotency"
 	services "payment-service/services/payment"
 )
 
 type Registry struct {
 	repository repositories.IRepositoryRegistry
 	kafka      kafka.IKafkaRegistry
-	midtrans   clients.IMidtransClient
+	gateway    clients.IGatewayRegistry
 }
 
 type IServiceRegistry interface {
 	GetPayment() services.IPaymentService
+	GetDeadLetter() deadLetterService.IDeadLetterService
+	GetIdempotency() idempotencyService.IIdempotencyService
 }
 
-func NewServiceRegistry(repository repositories.IRepositoryRegistry, kafka kafka.IKafkaRegistry, midtrans clients.IMidtransClient) IServiceRegistry {
+func NewServiceRegistry(repository repositories.IRepositoryRegistry, kafka kafka.IKafkaRegistry, gateway clients.IGatewayRegistry) IServiceRegistry {
 	return &Registry{
 		repository: repository,
 		kafka:      kafka,
-		midtrans:   midtrans,
+		gateway:    gateway,
 	}
 }
 
 func (r *Registry) GetPayment() services.IPaymentService {
-	return services.NewPaymentService(r.repository, r.kafka, r.midtrans)
+	return services.NewPaymentService(r.repository, r.kafka, r.gateway)
+}
+
+func (r *Registry) GetDeadLetter() deadLetterService.IDeadLetterService {
+	return deadLetterService.NewDeadLetterService(r.repository, r.kafka)
+}
+
+func (r *Registry) GetIdempotency() idempotencyService.IIdempotencyService {
+	return idempotencyService.NewIdempotencyService(r.repository)
 }
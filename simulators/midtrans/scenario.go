@@ -0,0 +1,57 @@
+package simulators
+
+import (
+	"fmt"
+	"payment-service/constants"
+	"sort"
+	"strings"
+	"time"
+)
+
+type Step struct {
+	Status constants.PaymentStatusString
+	Delay  time.Duration
+}
+
+type Scenario []Step
+
+var scenarios = map[string]Scenario{
+	"pending": {
+		{Status: constants.PendingString},
+	},
+	"settlement": {
+		{Status: constants.PendingString},
+		{Status: constants.SettlementString, Delay: 2 * time.Second},
+	},
+	"expire": {
+		{Status: constants.PendingString},
+		{Status: constants.ExpireString, Delay: 2 * time.Second},
+	},
+	"cancel": {
+		{Status: constants.PendingString},
+		{Status: constants.CancelString, Delay: 2 * time.Second},
+	},
+	"refund": {
+		{Status: constants.PendingString},
+		{Status: constants.SettlementString, Delay: 2 * time.Second},
+		{Status: constants.RefundString, Delay: 2 * time.Second},
+	},
+}
+
+func GetScenario(name string) (Scenario, error) {
+	scenario, ok := scenarios[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown scenario %q, available: %s", name, strings.Join(ScenarioNames(), ", "))
+	}
+
+	return scenario, nil
+}
+
+func ScenarioNames() []string {
+	names := make([]string, 0, len(scenarios))
+	for name := range scenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,362 @@
+package simulators
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/midtrans/midtrans-go/coreapi"
+	"github.com/midtrans/midtrans-go/snap"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"payment-service/common/util"
+	"payment-service/constants"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	merchantID      = "SIMULATOR"
+	transactionTime = "2006-01-02 15:04:05"
+)
+
+var statusCodes = map[constants.PaymentStatusString]string{
+	constants.PendingString:       "201",
+	constants.DenyString:          "202",
+	constants.ExpireString:        "407",
+	constants.FailureString:       "202",
+	constants.ChallengeString:     "201",
+	constants.CaptureString:       "200",
+	constants.SettlementString:    "200",
+	constants.CancelString:        "200",
+	constants.RefundString:        "200",
+	constants.PartialRefundString: "200",
+}
+
+type Simulator struct {
+	serverKey    string
+	baseURL      string
+	webhookURL   string
+	scenario     Scenario
+	httpClient   *http.Client
+	mutex        sync.RWMutex
+	transactions map[string]*coreapi.TransactionStatusResponse
+}
+
+type ISimulator interface {
+	Handler() http.Handler
+	Notify(orderID string, status constants.PaymentStatusString) error
+	Play(ctx context.Context, orderID string, scenario Scenario) error
+}
+
+func NewSimulator(serverKey, baseURL, webhookURL string, scenario Scenario) *Simulator {
+	return &Simulator{
+		serverKey:    serverKey,
+		baseURL:      strings.TrimSuffix(baseURL, "/"),
+		webhookURL:   webhookURL,
+		scenario:     scenario,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+		transactions: make(map[string]*coreapi.TransactionStatusResponse),
+	}
+}
+
+func (s *Simulator) Handler() http.Handler {
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	authorized := router.Group("/", s.authenticate)
+	authorized.POST("/snap/v1/transactions", s.createTransaction)
+	authorized.GET("/v2/:orderID/status", s.getStatus)
+	authorized.POST("/v2/:orderID/cancel", s.changeStatus(constants.CancelString))
+	authorized.POST("/v2/:orderID/expire", s.changeStatus(constants.ExpireString))
+	authorized.POST("/v2/:orderID/refund", s.refund)
+
+	router.GET("/snap/v2/vtweb/:token", s.paymentPage)
+	router.POST("/simulator/transactions/:orderID/:status", s.notify)
+	return router
+}
+
+func (s *Simulator) authenticate(c *gin.Context) {
+	username, _, ok := c.Request.BasicAuth()
+	if !ok || username != s.serverKey {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"status_code":    "401",
+			"status_message": "Access denied, please check client or server key",
+			"error_messages": []string{"Access denied, please check client or server key"},
+		})
+		return
+	}
+
+	c.Next()
+}
+
+func (s *Simulator) notFound(c *gin.Context) {
+	c.JSON(http.StatusNotFound, gin.H{
+		"status_code":    "404",
+		"status_message": "Transaction doesn't exist.",
+	})
+}
+
+func (s *Simulator) createTransaction(c *gin.Context) {
+	var request snap.Request
+	err := c.ShouldBindJSON(&request)
+	if err != nil || request.TransactionDetails.OrderID == "" {
+		c.JSON(http.StatusBadRequest, snap.Response{
+			StatusCode:    "400",
+			ErrorMessages: []string{"transaction_details.order_id is required"},
+		})
+		return
+	}
+
+	s.mutex.Lock()
+	if _, exist := s.transactions[request.TransactionDetails.OrderID]; exist {
+		s.mutex.Unlock()
+		c.JSON(http.StatusConflict, snap.Response{
+			StatusCode:    "409",
+			ErrorMessages: []string{"transaction_details.order_id has already been taken"},
+		})
+		return
+	}
+
+	token := uuid.New().String()
+	s.transactions[request.TransactionDetails.OrderID] = &coreapi.TransactionStatusResponse{
+		TransactionTime:   time.Now().Format(transactionTime),
+		GrossAmount:       fmt.Sprintf("%d.00", request.TransactionDetails.GrossAmt),
+		Currency:          "IDR",
+		OrderID:           request.TransactionDetails.OrderID,
+		PaymentType:       "bank_transfer",
+		StatusCode:        statusCodes[constants.PendingString],
+		TransactionID:     uuid.New().String(),
+		TransactionStatus: string(constants.PendingString),
+		FraudStatus:       string(constants.FraudAccept),
+		MerchantID:        merchantID,
+		VaNumbers: []coreapi.VANumber{
+			{Bank: "bca", VANumber: strconv.FormatInt(time.Now().UnixNano()%1e11, 10)},
+		},
+		Acquirer: "bca",
+	}
+	s.mutex.Unlock()
+
+	if len(s.scenario) > 0 {
+		go func(orderID string) {
+			err := s.Play(context.Background(), orderID, s.scenario)
+			if err != nil {
+				logrus.Errorf("failed play scenario for order %s: %v", orderID, err)
+			}
+		}(request.TransactionDetails.OrderID)
+	}
+
+	c.JSON(http.StatusCreated, snap.Response{
+		Token:       token,
+		RedirectURL: fmt.Sprintf("%s/snap/v2/vtweb/%s", s.baseURL, token),
+	})
+}
+
+func (s *Simulator) paymentPage(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"token":   c.Param("token"),
+		"message": "Midtrans simulator payment page, use /simulator/transactions/:orderID/:status to pay",
+	})
+}
+
+func (s *Simulator) find(orderID string) (coreapi.TransactionStatusResponse, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	transaction, ok := s.transactions[orderID]
+	if !ok {
+		return coreapi.TransactionStatusResponse{}, false
+	}
+
+	return *transaction, true
+}
+
+func (s *Simulator) getStatus(c *gin.Context) {
+	transaction, ok := s.find(c.Param("orderID"))
+	if !ok {
+		s.notFound(c)
+		return
+	}
+
+	transaction.StatusMessage = "Success, transaction is found"
+	c.JSON(http.StatusOK, transaction)
+}
+
+func (s *Simulator) changeStatus(status constants.PaymentStatusString) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		transaction, err := s.transition(c.Param("orderID"), status)
+		if err != nil {
+			s.notFound(c)
+			return
+		}
+
+		go s.fire(transaction)
+		c.JSON(http.StatusOK, coreapi.CancelResponse{
+			StatusCode:        transaction.StatusCode,
+			StatusMessage:     fmt.Sprintf("Success, transaction is %s", status),
+			TransactionID:     transaction.TransactionID,
+			OrderID:           transaction.OrderID,
+			GrossAmount:       transaction.GrossAmount,
+			Currency:          transaction.Currency,
+			PaymentType:       transaction.PaymentType,
+			TransactionTime:   transaction.TransactionTime,
+			TransactionStatus: transaction.TransactionStatus,
+			FraudStatus:       transaction.FraudStatus,
+		})
+	}
+}
+
+func (s *Simulator) refund(c *gin.Context) {
+	var request coreapi.RefundReq
+	_ = c.ShouldBindJSON(&request)
+
+	current, ok := s.find(c.Param("orderID"))
+	if !ok {
+		s.notFound(c)
+		return
+	}
+
+	grossAmount, _ := strconv.ParseFloat(current.GrossAmount, 64)
+	status := constants.RefundString
+	if request.Amount > 0 && float64(request.Amount) < grossAmount {
+		status = constants.PartialRefundString
+	}
+
+	refundAmount := current.GrossAmount
+	if request.Amount > 0 {
+		refundAmount = fmt.Sprintf("%d.00", request.Amount)
+	}
+
+	transaction, err := s.transition(current.OrderID, status, coreapi.RefundDetails{
+		RefundAmount: refundAmount,
+		Reason:       request.Reason,
+		RefundKey:    request.RefundKey,
+		CreatedAt:    time.Now().Format(transactionTime),
+	})
+	if err != nil {
+		s.notFound(c)
+		return
+	}
+
+	go s.fire(transaction)
+	c.JSON(http.StatusOK, coreapi.RefundResponse{
+		StatusCode:        transaction.StatusCode,
+		StatusMessage:     "Success, refund request is approved",
+		TransactionID:     transaction.TransactionID,
+		OrderID:           transaction.OrderID,
+		GrossAmount:       transaction.GrossAmount,
+		Currency:          transaction.Currency,
+		PaymentType:       transaction.PaymentType,
+		TransactionTime:   transaction.TransactionTime,
+		TransactionStatus: transaction.TransactionStatus,
+		RefundAmount:      refundAmount,
+		RefundKey:         request.RefundKey,
+	})
+}
+
+func (s *Simulator) notify(c *gin.Context) {
+	status := constants.PaymentStatusString(c.Param("status"))
+	if !status.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("unknown status %s", status)})
+		return
+	}
+
+	err := s.Notify(c.Param("orderID"), status)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "notification sent"})
+}
+
+func (s *Simulator) transition(orderID string, status constants.PaymentStatusString, refunds ...coreapi.RefundDetails) (coreapi.TransactionStatusResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	transaction, ok := s.transactions[orderID]
+	if !ok {
+		return coreapi.TransactionStatusResponse{}, fmt.Errorf("transaction %s not found", orderID)
+	}
+
+	transaction.TransactionStatus = string(status)
+	transaction.StatusCode = statusCodes[status]
+	if status == constants.SettlementString {
+		transaction.SettlementTime = time.Now().Format(transactionTime)
+	}
+	transaction.Refunds = append(transaction.Refunds, refunds...)
+
+	return *transaction, nil
+}
+
+func (s *Simulator) sign(transaction *coreapi.TransactionStatusResponse) {
+	transaction.SignatureKey = util.GenerateSHA512(fmt.Sprintf("%s%s%s%s",
+		transaction.OrderID,
+		transaction.StatusCode,
+		transaction.GrossAmount,
+		s.serverKey,
+	))
+}
+
+func (s *Simulator) fire(transaction coreapi.TransactionStatusResponse) {
+	err := s.send(transaction)
+	if err != nil {
+		logrus.Errorf("failed send notification for order %s: %v", transaction.OrderID, err)
+	}
+}
+
+func (s *Simulator) send(transaction coreapi.TransactionStatusResponse) error {
+	if s.webhookURL == "" {
+		return nil
+	}
+
+	s.sign(&transaction)
+	transaction.StatusMessage = "midtrans payment notification"
+	body, err := json.Marshal(transaction)
+	if err != nil {
+		return err
+	}
+
+	response, err := s.httpClient.Post(s.webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook responded with status %d", response.StatusCode)
+	}
+
+	logrus.Infof("sent %s notification for order %s", transaction.TransactionStatus, transaction.OrderID)
+	return nil
+}
+
+func (s *Simulator) Notify(orderID string, status constants.PaymentStatusString) error {
+	transaction, err := s.transition(orderID, status)
+	if err != nil {
+		return err
+	}
+
+	return s.send(transaction)
+}
+
+func (s *Simulator) Play(ctx context.Context, orderID string, scenario Scenario) error {
+	for _, step := range scenario {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(step.Delay):
+		}
+
+		err := s.Notify(orderID, step.Status)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package simulators
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	midtransClient "payment-service/clients/midtrans"
+	"payment-service/common/money"
+	"payment-service/constants"
+	errConstant "payment-service/constants/error/payment"
+	"payment-service/domain/dto"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testServerKey = "SB-Mid-server-test"
+
+// webhook stands in for the payment webhook: it verifies every notification
+// through the midtrans adapter and replays it against the status transition table.
+type webhook struct {
+	mutex      sync.Mutex
+	client     *midtransClient.MidtransClient
+	statuses   map[string]constants.PaymentStatus
+	received   map[string][]constants.PaymentStatusString
+	violations []string
+	notified   chan string
+}
+
+func newWebhook(client *midtransClient.MidtransClient) *webhook {
+	return &webhook{
+		client:   client,
+		statuses: map[string]constants.PaymentStatus{},
+		received: map[string][]constants.PaymentStatusString{},
+		notified: make(chan string, 100),
+	}
+}
+
+func (w *webhook) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	body, _ := io.ReadAll(request.Body)
+	transaction, err := w.client.ParseNotification(request.Header, body)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	orderID := transaction.OrderID.String()
+	next := transaction.Status.GetStatusInt()
+
+	w.mutex.Lock()
+	current := w.statuses[orderID]
+	if current.CanTransitionTo(next) {
+		w.statuses[orderID] = next
+	} else {
+		w.violations = append(w.violations, orderID+": "+string(current.GetStatusString())+" -> "+string(transaction.Status))
+	}
+	w.received[orderID] = append(w.received[orderID], transaction.Status)
+	w.mutex.Unlock()
+
+	writer.WriteHeader(http.StatusOK)
+	w.notified <- orderID
+}
+
+func (w *webhook) wait(t *testing.T, orderID string, count int) []constants.PaymentStatusString {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		w.mutex.Lock()
+		received := append([]constants.PaymentStatusString{}, w.received[orderID]...)
+		w.mutex.Unlock()
+		if len(received) >= count {
+			return received
+		}
+
+		select {
+		case <-w.notified:
+		case <-timeout:
+			t.Fatalf("received %d of %d notifications for %s", len(received), count, orderID)
+		}
+	}
+}
+
+func (w *webhook) status(orderID string) constants.PaymentStatus {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.statuses[orderID]
+}
+
+func (w *webhook) snapshot() []string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return append([]string{}, w.violations...)
+}
+
+func instant(scenario Scenario) Scenario {
+	steps := make(Scenario, 0, len(scenario))
+	for _, step := range scenario {
+		steps = append(steps, Step{Status: step.Status})
+	}
+
+	return steps
+}
+
+func startSimulator(t *testing.T, scenario Scenario) (*midtransClient.MidtransClient, *webhook) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	server := httptest.NewUnstartedServer(nil)
+	client := midtransClient.NewMidtransClient(testServerKey, false, "http://"+server.Listener.Addr().String())
+
+	receiver := newWebhook(client)
+	webhookServer := httptest.NewServer(receiver)
+	t.Cleanup(webhookServer.Close)
+
+	server.Config.Handler = NewSimulator(testServerKey, "http://"+server.Listener.Addr().String(), webhookServer.URL, scenario).Handler()
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return client, receiver
+}
+
+func createPayment(t *testing.T, client *midtransClient.MidtransClient) string {
+	t.Helper()
+
+	orderID := uuid.NewString()
+	link, err := client.CreatePaymentLink(&dto.PaymentRequest{
+		OrderID:   orderID,
+		Amount:    money.New(15000000, "IDR"),
+		ExpiredAt: time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("CreatePaymentLink() error = %v", err)
+	}
+	if link.RedirectURL == "" || link.Token == "" {
+		t.Fatalf("payment link = %+v", link)
+	}
+
+	return orderID
+}
+
+func TestScenarios(t *testing.T) {
+	for _, name := range ScenarioNames() {
+		t.Run(name, func(t *testing.T) {
+			scenario, err := GetScenario(name)
+			if err != nil {
+				t.Fatalf("GetScenario() error = %v", err)
+			}
+
+			client, receiver := startSimulator(t, instant(scenario))
+			orderID := createPayment(t, client)
+
+			received := receiver.wait(t, orderID, len(scenario))
+			for i, step := range scenario {
+				if received[i] != step.Status {
+					t.Errorf("notification %d = %s, want %s", i, received[i], step.Status)
+				}
+			}
+
+			if violations := receiver.snapshot(); len(violations) > 0 {
+				t.Errorf("invalid transitions: %v", violations)
+			}
+
+			final := scenario[len(scenario)-1].Status
+			if status := receiver.status(orderID); status != final.GetStatusInt() {
+				t.Errorf("final status = %s, want %s", status.GetStatusString(), final)
+			}
+
+			transaction, err := client.GetTransaction(orderID)
+			if err != nil {
+				t.Fatalf("GetTransaction() error = %v", err)
+			}
+			if transaction.Status != final || transaction.GrossAmount != "150000.00" {
+				t.Errorf("gateway transaction = %s %s, want %s 150000.00", transaction.Status, transaction.GrossAmount, final)
+			}
+		})
+	}
+}
+
+func TestCancelPendingPayment(t *testing.T) {
+	scenario, _ := GetScenario("pending")
+	client, receiver := startSimulator(t, instant(scenario))
+	orderID := createPayment(t, client)
+	receiver.wait(t, orderID, 1)
+
+	if err := client.CancelTransaction(orderID); err != nil {
+		t.Fatalf("CancelTransaction() error = %v", err)
+	}
+
+	received := receiver.wait(t, orderID, 2)
+	if received[1] != constants.CancelString {
+		t.Errorf("notification = %s, want %s", received[1], constants.CancelString)
+	}
+	if violations := receiver.snapshot(); len(violations) > 0 {
+		t.Errorf("invalid transitions: %v", violations)
+	}
+}
+
+func TestNotificationAfterFinalStatusIsRejected(t *testing.T) {
+	scenario, _ := GetScenario("expire")
+	client, receiver := startSimulator(t, instant(scenario))
+	orderID := createPayment(t, client)
+	receiver.wait(t, orderID, len(scenario))
+
+	if err := client.ExpireTransaction(orderID); err != nil {
+		t.Fatalf("ExpireTransaction() error = %v", err)
+	}
+	receiver.wait(t, orderID, len(scenario)+1)
+
+	if violations := receiver.snapshot(); len(violations) != 1 {
+		t.Errorf("violations = %v, want the repeated expire to be rejected", violations)
+	}
+	if status := receiver.status(orderID); status != constants.Expire {
+		t.Errorf("final status = %s, want %s", status.GetStatusString(), constants.ExpireString)
+	}
+}
+
+func TestInvalidServerKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := httptest.NewServer(NewSimulator(testServerKey, "", "", nil).Handler())
+	t.Cleanup(server.Close)
+
+	client := midtransClient.NewMidtransClient("wrong-key", false, server.URL)
+	_, err := client.CreatePaymentLink(&dto.PaymentRequest{
+		OrderID:   uuid.NewString(),
+		Amount:    money.New(15000000, "IDR"),
+		ExpiredAt: time.Now().Add(time.Hour),
+	})
+	if err == nil {
+		t.Errorf("CreatePaymentLink() with a wrong server key should fail")
+	}
+
+	if _, err = client.GetTransaction(uuid.NewString()); err == nil {
+		t.Errorf("GetTransaction() with a wrong server key should fail")
+	}
+}
+
+func TestUnknownTransaction(t *testing.T) {
+	client, _ := startSimulator(t, nil)
+
+	_, err := client.GetTransaction(uuid.NewString())
+	if !errors.Is(err, errConstant.ErrTransactionNotFound) {
+		t.Errorf("GetTransaction() error = %v, want %v", err, errConstant.ErrTransactionNotFound)
+	}
+
+	if err = NewSimulator(testServerKey, "", "", nil).Notify(uuid.NewString(), constants.SettlementString); err == nil {
+		t.Errorf("Notify() for an unknown transaction should fail")
+	}
+}
@@ -0,0 +1,91 @@
+package workers
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"payment-service/config"
+	"payment-service/constants"
+	"payment-service/domain/dto"
+	"payment-service/repositories"
+	paymentService "payment-service/services/payment"
+	"time"
+)
+
+const (
+	defaultIntervalInSecond = 60
+	defaultBatchSize        = 100
+)
+
+type ExpirySweeper struct {
+	repository repositories.IRepositoryRegistry
+	payment    paymentService.IPaymentService
+}
+
+type IExpirySweeper interface {
+	Run(context.Context)
+	Sweep(context.Context) (*dto.ExpireOverdueReport, error)
+}
+
+func NewExpirySweeper(repository repositories.IRepositoryRegistry, payment paymentService.IPaymentService) IExpirySweeper {
+	return &ExpirySweeper{
+		repository: repository,
+		payment:    payment,
+	}
+}
+
+func (e *ExpirySweeper) interval() time.Duration {
+	intervalInSecond := config.Config.Expiry.IntervalInSecond
+	if intervalInSecond <= 0 {
+		intervalInSecond = defaultIntervalInSecond
+	}
+
+	return time.Duration(intervalInSecond) * time.Second
+}
+
+func (e *ExpirySweeper) batchSize() int {
+	if config.Config.Expiry.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+
+	return config.Config.Expiry.BatchSize
+}
+
+func (e *ExpirySweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := e.Sweep(ctx)
+			if err != nil {
+				logrus.Errorf("failed sweep expired payments: %v", err)
+			}
+		}
+	}
+}
+
+func (e *ExpirySweeper) Sweep(ctx context.Context) (*dto.ExpireOverdueReport, error) {
+	release, acquired, err := e.repository.GetLock().TryAcquireSession(ctx, constants.ExpirySweepLockKey)
+	if err != nil || !acquired {
+		return nil, err
+	}
+	defer release()
+
+	report, err := e.payment.ExpireOverdue(ctx, &dto.ExpireOverdueRequest{
+		ExpiredBefore: time.Now(),
+		Limit:         e.batchSize(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if report.Scanned > 0 {
+		logrus.Infof("swept %d overdue payments: %d expired, %d skipped, %d failed",
+			report.Scanned, report.Expired, report.Skipped, report.Failed)
+	}
+
+	return report, nil
+}
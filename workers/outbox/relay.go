@@ -0,0 +1,169 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"payment-service/common/util"
+	"payment-service/config"
+	"payment-service/constants"
+	"payment-service/controllers/kafka"
+	"payment-service/domain/dto"
+	"payment-service/domain/models"
+	"payment-service/repositories"
+	deadLetterService "payment-service/services/dead_letter"
+	"time"
+)
+
+const (
+	defaultIntervalInMS = 1000
+	defaultBatchSize    = 100
+	defaultMaxRetry     = 10
+)
+
+var backlogSize = expvar.NewInt("outbox_backlog_size")
+
+type OutboxRelay struct {
+	repository repositories.IRepositoryRegistry
+	kafka      kafka.IKafkaRegistry
+	deadLetter deadLetterService.IDeadLetterService
+}
+
+type IOutboxRelay interface {
+	Run(context.Context)
+	RelayPending(context.Context) (int, error)
+}
+
+func NewOutboxRelay(repository repositories.IRepositoryRegistry, kafka kafka.IKafkaRegistry, deadLetter deadLetterService.IDeadLetterService) IOutboxRelay {
+	return &OutboxRelay{
+		repository: repository,
+		kafka:      kafka,
+		deadLetter: deadLetter,
+	}
+}
+
+func (o *OutboxRelay) interval() time.Duration {
+	intervalInMS := config.Config.Outbox.IntervalInMS
+	if intervalInMS <= 0 {
+		intervalInMS = defaultIntervalInMS
+	}
+
+	return time.Duration(intervalInMS) * time.Millisecond
+}
+
+func (o *OutboxRelay) batchSize() int {
+	if config.Config.Outbox.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+
+	return config.Config.Outbox.BatchSize
+}
+
+func (o *OutboxRelay) maxRetry() int {
+	if config.Config.Outbox.MaxRetry <= 0 {
+		return defaultMaxRetry
+	}
+
+	return config.Config.Outbox.MaxRetry
+}
+
+func (o *OutboxRelay) deadLetterOutbox(ctx context.Context, tx *gorm.DB, outbox *models.Outbox, headers map[string]string, cause error) error {
+	logrus.Errorf("outbox %s exhausted %d attempts: %v", outbox.UUID, outbox.Attempts+1, cause)
+	err := o.repository.GetOutbox().MarkFailed(ctx, tx, outbox, cause)
+	if err != nil {
+		return err
+	}
+
+	return o.deadLetter.Create(ctx, tx, &dto.DeadLetterRequest{
+		Source:     constants.DeadLetterProducer,
+		Topic:      outbox.Topic,
+		MessageKey: &outbox.AggregateID,
+		Headers:    headers,
+		Payload:    []byte(outbox.Payload),
+		Error:      cause.Error(),
+		Attempts:   outbox.Attempts + 1,
+	})
+}
+
+func (o *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(o.interval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := o.RelayPending(ctx)
+			if err != nil {
+				logrus.Errorf("failed relay outbox: %v", err)
+			}
+		}
+	}
+}
+
+func (o *OutboxRelay) RelayPending(ctx context.Context) (int, error) {
+	var published int
+
+	err := o.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		acquired, err := o.repository.GetLock().TryAcquire(ctx, tx, constants.OutboxRelayLockKey)
+		if err != nil || !acquired {
+			return err
+		}
+
+		outboxes, err := o.repository.GetOutbox().FindPending(ctx, tx, o.batchSize())
+		if err != nil {
+			return err
+		}
+
+		blocked := make(map[string]bool)
+		now := time.Now()
+		for _, outbox := range outboxes {
+			if blocked[outbox.AggregateID] {
+				continue
+			}
+
+			if outbox.NextAttemptAt.After(now) {
+				blocked[outbox.AggregateID] = true
+				continue
+			}
+
+			var headers map[string]string
+			_ = json.Unmarshal([]byte(outbox.Headers), &headers)
+			err = o.kafka.GetKafkaProducer().ProducerMessage(outbox.Topic, outbox.AggregateID, []byte(outbox.Payload), headers)
+			if err != nil {
+				blocked[outbox.AggregateID] = true
+				if outbox.Attempts+1 >= o.maxRetry() {
+					err = o.deadLetterOutbox(ctx, tx, &outbox, headers, err)
+				} else {
+					err = o.repository.GetOutbox().MarkRetry(ctx, tx, &outbox, err, now.Add(util.Backoff(o.interval(), outbox.Attempts)))
+				}
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			err = o.repository.GetOutbox().MarkPublished(ctx, tx, outbox.ID)
+			if err != nil {
+				return err
+			}
+			published++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return published, err
+	}
+
+	total, err := o.repository.GetOutbox().CountPending(ctx)
+	if err != nil {
+		return published, err
+	}
+	backlogSize.Set(total)
+
+	return published, nil
+}
@@ -0,0 +1,113 @@
+package workers
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"payment-service/config"
+	"payment-service/constants"
+	"payment-service/domain/dto"
+	"payment-service/repositories"
+	paymentService "payment-service/services/payment"
+	"sync"
+	"time"
+)
+
+const (
+	defaultIntervalInSecond    = 300
+	defaultGracePeriodInSecond = 900
+	defaultBatchSize           = 100
+)
+
+type ReconcileWorker struct {
+	repository repositories.IRepositoryRegistry
+	payment    paymentService.IPaymentService
+	mutex      sync.Mutex
+}
+
+type IReconcileWorker interface {
+	Run(context.Context)
+	Reconcile(context.Context) (*dto.ReconcileReport, error)
+}
+
+func NewReconcileWorker(repository repositories.IRepositoryRegistry, payment paymentService.IPaymentService) IReconcileWorker {
+	return &ReconcileWorker{
+		repository: repository,
+		payment:    payment,
+	}
+}
+
+func (r *ReconcileWorker) interval() time.Duration {
+	intervalInSecond := config.Config.Reconcile.IntervalInSecond
+	if intervalInSecond <= 0 {
+		intervalInSecond = defaultIntervalInSecond
+	}
+
+	return time.Duration(intervalInSecond) * time.Second
+}
+
+func (r *ReconcileWorker) gracePeriod() time.Duration {
+	gracePeriodInSecond := config.Config.Reconcile.GracePeriodInSecond
+	if gracePeriodInSecond <= 0 {
+		gracePeriodInSecond = defaultGracePeriodInSecond
+	}
+
+	return time.Duration(gracePeriodInSecond) * time.Second
+}
+
+func (r *ReconcileWorker) batchSize() int {
+	if config.Config.Reconcile.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+
+	return config.Config.Reconcile.BatchSize
+}
+
+func (r *ReconcileWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := r.Reconcile(ctx)
+			if err != nil {
+				logrus.Errorf("failed reconcile payments: %v", err)
+			}
+		}
+	}
+}
+
+func (r *ReconcileWorker) Reconcile(ctx context.Context) (*dto.ReconcileReport, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	release, acquired, err := r.repository.GetLock().TryAcquireSession(ctx, constants.ReconcileLockKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if !acquired {
+		logrus.Infof("reconciliation skipped, another instance holds the lock")
+		return nil, nil
+	}
+	defer release()
+
+	report, err := r.payment.Reconcile(ctx, &dto.ReconcileRequest{
+		UpdatedBefore: time.Now().Add(-r.gracePeriod()),
+		Limit:         r.batchSize(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("reconciled %d payments: %d in sync, %d applied, %d rejected, %d not found, %d failed",
+		report.Scanned, report.InSync, report.Applied, report.Rejected, report.NotFound, report.Failed)
+	for _, discrepancy := range report.Discrepancies {
+		logrus.Warnf("reconcile %s order %s (%s): local %s, gateway %s",
+			discrepancy.Action, discrepancy.OrderID, discrepancy.Provider, discrepancy.LocalStatus, discrepancy.GatewayStatus)
+	}
+
+	return report, nil
+}